@@ -12,19 +12,31 @@ import (
 
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/razorpay/go-financial/enums/brokenperiod"
 	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/rowtype"
 )
 
 // Amortization struct holds the configuration and financial details.
 type Amortization struct {
-	Config    *Config
-	Financial Financial
+	Config            *Config
+	Financial         Financial
+	ResidualAllocator ResidualAllocator
 }
 
 // NewAmortization return a new amortisation object with config and financial fields initialised.
 func NewAmortization(c *Config) (*Amortization, error) {
+	return newAmortization(c, 0)
+}
+
+// newAmortization returns an amortization for a schedule up to the end date of the config, or for the given number of
+// regular periods if the tenure is not zero.
+func newAmortization(c *Config, tenure int64) (*Amortization, error) {
+	if err := c.validate(tenure); err != nil {
+		return nil, err
+	}
 	a := Amortization{Config: c}
-	if err := a.Config.setPeriodsAndDates(); err != nil {
+	if err := a.Config.setPeriodsAndDates(tenure); err != nil {
 		return nil, err
 	}
 	switch a.Config.InterestType {
@@ -32,49 +44,192 @@ func NewAmortization(c *Config) (*Amortization, error) {
 		a.Financial = &Reducing{}
 	case interesttype.FLAT:
 		a.Financial = &Flat{}
+	case interesttype.BULLET:
+		a.Financial = &Bullet{}
+	case interesttype.EQUAL_PRINCIPAL:
+		a.Financial = &EqualPrincipal{}
+	case interesttype.RULE_OF_78:
+		a.Financial = &RuleOf78{}
 	}
+	a.ResidualAllocator = getResidualAllocator(a.Config.ResidualAllocation)
 	return &a, nil
 }
 
+// NewAmortizationWithTenure returns an amortization for a schedule of the given number of regular periods from the
+// start date, excluding any broken period. The end date of the config is derived from the tenure, and any EndDate
+// already set is overwritten.
+func NewAmortizationWithTenure(c *Config, tenure int64) (*Amortization, error) {
+	if tenure <= 0 {
+		return nil, fmt.Errorf("%w: %d periods, must be at least one period", ErrInvalidTenure, tenure)
+	}
+	return newAmortization(c, tenure)
+}
+
 // Row represents a single row in an amortization schedule.
+// Payment, interest and principal columns are -ve, while balances are +ve like Config.AmountBorrowed.
 type Row struct {
-	Period    int64
-	StartDate time.Time
-	EndDate   time.Time
-	Payment   decimal.Decimal
-	Interest  decimal.Decimal
-	Principal decimal.Decimal
+	Type                rowtype.Type // REGULAR, MORATORIUM, or BROKEN_PERIOD for the period(numbered 0) before the first regular period
+	Period              int64
+	StartDate           time.Time
+	EndDate             time.Time
+	OpeningBalance      decimal.Decimal // principal outstanding at the start of the period
+	Payment             decimal.Decimal
+	Interest            decimal.Decimal
+	Principal           decimal.Decimal
+	Prepayment          decimal.Decimal // part of the principal (and payment) that was prepaid in this period
+	ClosingBalance      decimal.Decimal // principal outstanding after the payment of the period
+	Residual            decimal.Decimal // rounding difference settled in this row, in the principal, or in the interest for a mismatch in [payment-(principal+interest)]
+	CumulativeInterest  decimal.Decimal // interest paid till this period(inclusive)
+	CumulativePrincipal decimal.Decimal // principal paid till this period(inclusive)
 }
 
 // GenerateTable constructs the amortization table based on the configuration.
 func (a Amortization) GenerateTable() ([]Row, error) {
 	var result []Row
-	for i := int64(1); i <= a.Config.periods; i++ {
+	// rate resets change the rate of interest, and may extend the schedule, of a copy of the config.
+	config := *a.Config
+	a.Config = &config
+	if err := a.Config.validateMoratorium(); err != nil {
+		return nil, err
+	}
+	if err := a.Config.validateBalloonAmount(); err != nil {
+		return nil, err
+	}
+	if err := a.Config.validateStep(); err != nil {
+		return nil, err
+	}
+	prepayments, err := a.Config.getPrepayments()
+	if err != nil {
+		return nil, err
+	}
+	resets, err := a.Config.getRateResets()
+	if err != nil {
+		return nil, err
+	}
+	// segment is the config used for the current (re-)amortisation of the outstanding principal, starting after
+	// the period given by offset.
+	segment := a.Config.getSegment(0, a.Config.AmountBorrowed, a.Config.periods)
+	offset := int64(0)
+	lastPeriod := a.Config.periods
+	outstanding := a.Config.AmountBorrowed
+	// drift is the difference of the rounded principal from the principal of the current segment, which is left over
+	// as the residual principal at the end of the schedule.
+	drift := decimal.Zero
+	// broken period interest to be collected along with the first installment after the moratorium, if any.
+	deferredInterest := decimal.Zero
+	if a.Config.hasBrokenPeriod() {
+		interest, err := a.Config.getBrokenPeriodInterest()
+		if err != nil {
+			return nil, err
+		}
+		if a.Config.EnableRounding {
+			interest = a.Config.round(interest)
+		}
+		row := a.getBrokenPeriodRow(interest)
+		result = append(result, row)
+		outstanding = row.ClosingBalance
+		switch a.Config.BrokenPeriodInterest {
+		case brokenperiod.ADD_TO_FIRST_INSTALLMENT:
+			deferredInterest = interest
+		case brokenperiod.CAPITALISE:
+			segment = a.Config.getSegment(0, outstanding, a.Config.periods)
+		}
+	}
+	if a.Config.Moratorium.Periods > 0 {
+		result = a.getMoratoriumRows(result, resets)
+		offset = a.Config.Moratorium.Periods
+		outstanding = result[len(result)-1].ClosingBalance
+		segment = a.Config.getSegment(offset, outstanding, a.Config.periods-offset)
+		// the moratorium ends with the period, so the payments are made as per the config.
+		segment.PaymentPeriod = a.Config.PaymentPeriod
+	}
+	for i := offset + 1; i <= lastPeriod; i++ {
+		if rate, ok := resets[i]; ok {
+			a.Config.Interest = rate
+			next, err := a.resetRate(getPaymentForPeriod(a.Financial, segment, i-offset), i, lastPeriod, outstanding)
+			if err != nil {
+				return nil, err
+			}
+			if offset == i-1 {
+				// the rate is reset at the start of the segment, so the payment period is unchanged.
+				next.PaymentPeriod = segment.PaymentPeriod
+			}
+			segment = next
+			offset = i - 1
+			lastPeriod = offset + segment.periods
+			drift = decimal.Zero
+		}
 		var row Row
+		row.Type = rowtype.REGULAR
 		row.Period = i
 		row.StartDate = a.Config.startDates[i-1]
 		row.EndDate = a.Config.endDates[i-1]
 
-		payment := a.Financial.GetPayment(*a.Config)
-		principalPayment := a.Financial.GetPrincipal(*a.Config, i)
-		interestPayment := a.Financial.GetInterest(*a.Config, i)
+		payment := getPaymentForPeriod(a.Financial, segment, i-offset)
+		principalPayment := a.Financial.GetPrincipal(segment, i-offset)
+		interestPayment := a.Financial.GetInterest(segment, i-offset)
 		if a.Config.EnableRounding {
-			row.Payment = payment.Round(a.Config.RoundingPlaces)
-			row.Principal = principalPayment.Round(a.Config.RoundingPlaces)
+			row.Payment = a.Config.round(payment)
+			row.Principal = a.Config.round(principalPayment)
 			// to avoid rounding errors.
 			row.Interest = row.Payment.Sub(row.Principal)
+			drift = drift.Add(row.Principal.Sub(principalPayment))
 		} else {
 			row.Payment = payment
 			row.Principal = principalPayment
 			row.Interest = interestPayment
 		}
-		if i == a.Config.periods {
-			DoPrincipalAdjustmentDueToRounding(&row, result, a.Config.AmountBorrowed, a.Config.EnableRounding, a.Config.RoundingPlaces)
+		if i == a.Config.Moratorium.Periods+1 {
+			row.Payment = row.Payment.Add(deferredInterest)
+			row.Interest = row.Interest.Add(deferredInterest)
+		}
+		prepayment, hasPrepayment := prepayments[i]
+		if hasPrepayment && i == lastPeriod {
+			return nil, fmt.Errorf("%w: period %d is the last period, in which the outstanding principal is repaid", ErrInvalidPrepayment, i)
+		}
+		if hasPrepayment {
+			// adding principal coz it is -ve.
+			balance := outstanding.Add(row.Principal)
+			if prepayment.GreaterThanOrEqual(balance) {
+				// the loan is foreclosed in this period.
+				prepayment = balance
+				lastPeriod = i
+				// the rounded principal is settled by the prepayment.
+				drift = decimal.Zero
+			}
+			row.Prepayment = prepayment.Neg()
+			row.Payment = row.Payment.Add(row.Prepayment)
+			row.Principal = row.Principal.Add(row.Prepayment)
+		}
+		if i == lastPeriod {
+			// also repays the principal left outstanding after the regular payments, i.e. the balloon amount or the
+			// principal of a bullet loan.
+			settleFinalRow(&row, outstanding, a.Config.EnableRounding, a.Config.RoundingPlaces)
+			// subtracting drift coz the residual is settled against the -ve principal.
+			drift = drift.Round(a.Config.RoundingPlaces)
+			row.Residual = drift.Neg()
 		}
 		if err := sanityCheckUpdate(&row, a.Config.RoundingErrorTolerance); err != nil {
 			return nil, err
 		}
+		setBalancesAndTotals(&row, result, a.Config.AmountBorrowed)
 		result = append(result, row)
+		outstanding = row.ClosingBalance
+		if hasPrepayment && i < lastPeriod {
+			segment = a.reamortise(getPaymentForPeriod(a.Financial, segment, i+1-offset), i, lastPeriod, outstanding)
+			offset = i
+			lastPeriod = i + segment.periods
+			drift = decimal.Zero
+		}
+	}
+	if err := validatePrepaymentPeriods(prepayments, lastPeriod); err != nil {
+		return nil, err
+	}
+	if residual := drift.Neg(); !residual.IsZero() && a.ResidualAllocator != nil {
+		a.ResidualAllocator.AllocateResidual(*a.Config, result, residual)
+		for i := range result {
+			setBalancesAndTotals(&result[i], result[:i], a.Config.AmountBorrowed)
+		}
 	}
 	return result, nil
 }
@@ -82,11 +237,19 @@ func (a Amortization) GenerateTable() ([]Row, error) {
 // DoPrincipalAdjustmentDueToRounding takes care of errors in total principal to be collected and adjusts it against the
 // the final principal and payment amount.
 func DoPrincipalAdjustmentDueToRounding(finalRow *Row, rows []Row, principal decimal.Decimal, round bool, places int32) {
-	principalCollected := finalRow.Principal
+	outstanding := principal
 	for _, row := range rows {
-		principalCollected = principalCollected.Add(row.Principal)
+		// adding principal coz it is -ve, or +ve if the interest is capitalised.
+		outstanding = outstanding.Add(row.Principal)
 	}
-	diff := principal.Abs().Sub(principalCollected.Abs())
+	settleFinalRow(finalRow, outstanding, round, places)
+}
+
+// settleFinalRow adjusts the principal and payment of the final row, so that it repays the principal outstanding
+// before it, including any interest capitalised during a moratorium or a broken period.
+func settleFinalRow(finalRow *Row, outstanding decimal.Decimal, round bool, places int32) {
+	// adding principal coz it is -ve.
+	diff := outstanding.Add(finalRow.Principal)
 	if round {
 		// subtracting diff coz payment, principal and interest are -ve.
 		finalRow.Payment = finalRow.Payment.Sub(diff).Round(places)
@@ -97,6 +260,50 @@ func DoPrincipalAdjustmentDueToRounding(finalRow *Row, rows []Row, principal dec
 	}
 }
 
+// getBrokenPeriodRow returns the row for the broken period before the first regular period. Depending on
+// Config.BrokenPeriodInterest, the interest for the broken period is either paid in this row, paid with the first
+// installment(so this row has no amounts), or capitalised, i.e. added to the principal with no payment.
+func (a Amortization) getBrokenPeriodRow(interest decimal.Decimal) Row {
+	row := Row{
+		Type:      rowtype.BROKEN_PERIOD,
+		Period:    0,
+		StartDate: a.Config.StartDate,
+		EndDate:   a.Config.brokenPeriodEndDate,
+	}
+	switch a.Config.BrokenPeriodInterest {
+	case brokenperiod.ADD_TO_FIRST_INSTALLMENT:
+		// collected along with the first installment.
+	case brokenperiod.CAPITALISE:
+		row.Interest = interest
+		// principal is +ve as the interest is added to the outstanding principal.
+		row.Principal = interest.Neg()
+	default:
+		row.Payment = interest
+		row.Interest = interest
+	}
+	setBalancesAndTotals(&row, nil, a.Config.AmountBorrowed)
+	return row
+}
+
+// setBalancesAndTotals sets the outstanding principal balances and the running totals of a row, carrying them
+// forward from the last of the previous rows. Since the balances are derived from the (rounded) principal of the rows,
+// the closing balance of the final row is zero.
+func setBalancesAndTotals(row *Row, rows []Row, principal decimal.Decimal) {
+	row.OpeningBalance = principal
+	row.CumulativeInterest = decimal.Zero
+	row.CumulativePrincipal = decimal.Zero
+	if len(rows) > 0 {
+		previous := rows[len(rows)-1]
+		row.OpeningBalance = previous.ClosingBalance
+		row.CumulativeInterest = previous.CumulativeInterest
+		row.CumulativePrincipal = previous.CumulativePrincipal
+	}
+	// adding principal coz it is -ve.
+	row.ClosingBalance = row.OpeningBalance.Add(row.Principal)
+	row.CumulativeInterest = row.CumulativeInterest.Add(row.Interest)
+	row.CumulativePrincipal = row.CumulativePrincipal.Add(row.Principal)
+}
+
 // sanityCheckUpdate verifies the equation,
 // payment = principal + interest for every row.
 // If there is a mismatch due to rounding error and it is withing the tolerance,
@@ -106,65 +313,51 @@ func sanityCheckUpdate(row *Row, tolerance decimal.Decimal) error {
 		diff := row.Payment.Abs().Sub(row.Principal.Add(row.Interest).Abs())
 		if diff.LessThanOrEqual(tolerance) {
 			row.Interest = row.Interest.Sub(diff)
+			row.Residual = row.Residual.Sub(diff)
 		} else {
-			return ErrPayment
+			return fmt.Errorf("%w: payment %v differs from principal %v plus interest %v in period %d by more than the tolerance %v", ErrPayment, row.Payment, row.Principal, row.Interest, row.Period, tolerance)
 		}
 	}
 	return nil
 }
 
-// PrintRows outputs a formatted json for given rows as input.
+// WriteRows writes a formatted json for given rows as input to the writer.
+func WriteRows(w io.Writer, rows []Row) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(rows)
+}
+
+// PrintRows outputs a formatted json for given rows as input, as written by WriteRows, to stdout. An error is printed
+// to stderr.
+//
+// Deprecated: use Amortization.WriteJSON, or WriteRows, which return the error.
 func PrintRows(rows []Row) {
-	bytes, _ := json.MarshalIndent(rows, "", "\t")
-	fmt.Printf("%s", bytes)
+	if err := WriteRows(os.Stdout, rows); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
 }
 
-// PlotRows uses the go-echarts package to generate an interactive plot from the Rows array.
+// PlotRows uses the go-echarts package to generate an interactive plot from the Rows array, in fileName.html in the
+// current working directory. SaveChart and WriteChart take the chart options, including the chart type.
 func PlotRows(rows []Row, fileName string) (err error) {
-	bar := getStackedBarPlot(rows)
 	completePath, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 	filePath := path.Join(completePath, fileName)
-	f, err := os.Create(fmt.Sprintf("%s.html", filePath))
-	if err != nil {
-		return err
-	}
-	defer func() {
-		// setting named err
-		ferr := f.Close()
-		if err == nil {
-			err = ferr
-		}
-	}()
-	return renderer(bar, f)
+	return SaveChart(rows, fmt.Sprintf("%s.html", filePath), ChartOptions{})
 }
 
 // getStackedBarPlot returns an instance for stacked bar plot.
 func getStackedBarPlot(rows []Row) *charts.Bar {
+	return getStackedBarChart(rows, ChartOptions{})
+}
+
+// getStackedBarChart returns a stacked bar chart of the principal and interest of every row, along with the payment.
+func getStackedBarChart(rows []Row, options ChartOptions) *charts.Bar {
 	bar := charts.NewBar()
-	bar.SetGlobalOptions(charts.WithTitleOpts(opts.Title{
-		Title: "Loan repayment schedule",
-	},
-	),
-		charts.WithInitializationOpts(opts.Initialization{
-			Width:  "1200px",
-			Height: "600px",
-		}),
-		charts.WithToolboxOpts(opts.Toolbox{Show: true}),
-		charts.WithLegendOpts(opts.Legend{Show: true}),
-		charts.WithDataZoomOpts(opts.DataZoom{
-			Type:  "inside",
-			Start: 0,
-			End:   50,
-		}),
-		charts.WithDataZoomOpts(opts.DataZoom{
-			Type:  "slider",
-			Start: 0,
-			End:   50,
-		}),
-	)
+	bar.SetGlobalOptions(options.getGlobalOptions("Loan repayment schedule", true)...)
 	var xAxis []string
 	var interestArr []opts.BarData
 	var principalArr []opts.BarData
@@ -182,6 +375,8 @@ func getStackedBarPlot(rows []Row) *charts.Bar {
 		AddSeries("Interest", interestArr).
 		AddSeries("Payment", paymentArr).SetSeriesOptions(
 		charts.WithBarChartOpts(opts.BarChart{
+			// the type of the series is reset by the bar chart options.
+			Type:  "bar",
 			Stack: "stackA",
 		}))
 	return bar
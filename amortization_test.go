@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -13,8 +16,19 @@ import (
 
 	"github.com/go-echarts/go-echarts/v2/charts"
 
+	"github.com/razorpay/go-financial/enums/brokenperiod"
+	"github.com/razorpay/go-financial/enums/businessday"
+	"github.com/razorpay/go-financial/enums/daycount"
+	"github.com/razorpay/go-financial/enums/dueday"
 	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/moratoriumtype"
 	"github.com/razorpay/go-financial/enums/paymentperiod"
+	"github.com/razorpay/go-financial/enums/prepaymentstrategy"
+	"github.com/razorpay/go-financial/enums/resetstrategy"
+	"github.com/razorpay/go-financial/enums/residualallocation"
+	"github.com/razorpay/go-financial/enums/roundingmode"
+	"github.com/razorpay/go-financial/enums/rowtype"
+	"github.com/razorpay/go-financial/enums/steptype"
 	"github.com/smartystreets/assertions"
 
 	"github.com/razorpay/go-financial/enums/frequency"
@@ -88,10 +102,1302 @@ func Test_amortization_GenerateTable(t *testing.T) {
 			if err := principalCheck(t, got, tt.fields.Config.AmountBorrowed); err != nil {
 				t.Fatal(err)
 			}
+			if err := balanceCheck(t, got, tt.fields.Config.AmountBorrowed); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func Test_amortization_GenerateTable_Features(t *testing.T) {
+	type test struct {
+		name         string
+		interestType interesttype.Type
+		update       func(config *Config)
+		wantLen      int
+		wantRows     map[int]Row
+		wantErr      error
+	}
+	// combine applies the updates of several features to a config in order.
+	combine := func(updates ...func(config *Config)) func(config *Config) {
+		return func(config *Config) {
+			for _, update := range updates {
+				update(config)
+			}
+		}
+	}
+	prepay := func(strategy prepaymentstrategy.Type, prepayments ...Prepayment) func(config *Config) {
+		return func(config *Config) {
+			config.Prepayments = prepayments
+			config.PrepaymentStrategy = strategy
+		}
+	}
+	dayCount := func(convention daycount.Type) func(config *Config) {
+		return func(config *Config) {
+			config.DayCountConvention = convention
+		}
+	}
+	brokenPeriod := func(brokenPeriodInterest brokenperiod.Type) func(config *Config) {
+		return func(config *Config) {
+			config.StartDate = time.Date(2020, 4, 17, 0, 0, 0, 0, time.UTC)
+			config.EndDate = time.Date(2022, 5, 5, 0, 0, 0, 0, time.UTC)
+			config.FirstDueDate = time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC)
+			config.BrokenPeriodInterest = brokenPeriodInterest
+		}
+	}
+	moratorium := func(moratorium Moratorium) func(config *Config) {
+		return func(config *Config) {
+			config.Moratorium = moratorium
+		}
+	}
+	rateReset := func(interest int64, strategy resetstrategy.Type) func(config *Config) {
+		return func(config *Config) {
+			config.RateResets = []RateReset{{Date: time.Date(2020, 10, 10, 0, 0, 0, 0, time.UTC), Interest: decimal.NewFromInt(interest)}}
+			config.RateResetStrategy = strategy
+		}
+	}
+	balloon := func(balloonAmount int64) func(config *Config) {
+		return func(config *Config) {
+			config.BalloonAmount = decimal.NewFromInt(balloonAmount)
+		}
+	}
+	step := func(step Step) func(config *Config) {
+		return func(config *Config) {
+			config.Step = step
+		}
+	}
+	compounding := func(compoundingFrequency frequency.Type) func(config *Config) {
+		return func(config *Config) {
+			config.CompoundingFrequency = compoundingFrequency
+		}
+	}
+	schedule := func(freq frequency.Type, startDate time.Time, endDate time.Time) func(config *Config) {
+		return func(config *Config) {
+			config.Frequency = freq
+			config.StartDate = startDate
+			config.EndDate = endDate
+		}
+	}
+	calendar := NewHolidayCalendar([]time.Weekday{time.Saturday, time.Sunday}, []time.Time{getDate(2020, 5, 14)})
+	businessDay := func(convention businessday.Type, accrue bool) func(config *Config) {
+		return func(config *Config) {
+			config.Calendar = calendar
+			config.BusinessDayConvention = convention
+			config.AccrueOnAdjustedDates = accrue
+		}
+	}
+	dueDayAnchor := func(anchor dueday.Type, endDate time.Time) func(config *Config) {
+		return func(config *Config) {
+			config.StartDate = getDate(2020, 1, 31)
+			config.EndDate = endDate
+			config.DueDayAnchor = anchor
+			config.DayCountConvention = daycount.ACT_365_FIXED
+		}
+	}
+	rounding := func(mode roundingmode.Type, increment decimal.Decimal) func(config *Config) {
+		return func(config *Config) {
+			config.RoundingMode = mode
+			config.RoundingIncrement = increment
+		}
+	}
+	beginning := func(config *Config) {
+		config.PaymentPeriod = paymentperiod.BEGINNING
+	}
+	// row returns a row of the default monthly schedule, starting on 2020-04-15.
+	row := func(period int, payment, interest, principal int64) Row {
+		start := time.Date(2020, time.Month(3+period), 15, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(0, 1, -1)
+		end = time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, time.UTC)
+		return Row{Period: int64(period), StartDate: start, EndDate: end, Payment: decimal.NewFromInt(payment), Interest: decimal.NewFromInt(interest), Principal: decimal.NewFromInt(principal)}
+	}
+	firstStart := timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC")
+	firstEnd := timeParseUtil(t, "2020-05-14 23:59:59 +0000 UTC")
+	secondStart := timeParseUtil(t, "2020-05-15 00:00:00 +0000 UTC")
+	secondEnd := timeParseUtil(t, "2020-06-14 23:59:59 +0000 UTC")
+	lastStart := timeParseUtil(t, "2022-03-15 00:00:00 +0000 UTC")
+	lastEnd := timeParseUtil(t, "2022-04-14 23:59:59 +0000 UTC")
+	brokenStart := timeParseUtil(t, "2020-04-17 00:00:00 +0000 UTC")
+	brokenEnd := timeParseUtil(t, "2020-05-05 23:59:59 +0000 UTC")
+	brokenFirstStart := timeParseUtil(t, "2020-05-06 00:00:00 +0000 UTC")
+	brokenFirstEnd := timeParseUtil(t, "2020-06-05 23:59:59 +0000 UTC")
+	brokenLastStart := timeParseUtil(t, "2022-04-06 00:00:00 +0000 UTC")
+	brokenLastEnd := timeParseUtil(t, "2022-05-05 23:59:59 +0000 UTC")
+	sixthRow := row(6, -52871, -16579, -36292)
+	tests := map[string][]test{
+		"prepayment": {
+			{
+				name:         "reducing interest, reduce emi",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					6:  {Period: 6, StartDate: timeParseUtil(t, "2020-09-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-10-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252871), Interest: decimal.NewFromInt(-16579), Principal: decimal.NewFromInt(-236292), Prepayment: decimal.NewFromInt(-200000)},
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-39531), Interest: decimal.NewFromInt(-11853), Principal: decimal.NewFromInt(-27678)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-39531), Interest: decimal.NewFromInt(-775), Principal: decimal.NewFromInt(-38756)},
+				},
+			},
+			{
+				name:         "reducing interest, reduce tenure, prepayment by date",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Date: getDate(2020, 10, 1), Amount: decimal.NewFromInt(200000)}),
+				wantLen:      19,
+				wantRows: map[int]Row{
+					6:  {Period: 6, StartDate: timeParseUtil(t, "2020-09-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-10-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252871), Interest: decimal.NewFromInt(-16579), Principal: decimal.NewFromInt(-236292), Prepayment: decimal.NewFromInt(-200000)},
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52223), Interest: decimal.NewFromInt(-11853), Principal: decimal.NewFromInt(-40370)},
+					19: {Period: 19, StartDate: timeParseUtil(t, "2021-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52223), Interest: decimal.NewFromInt(-1024), Principal: decimal.NewFromInt(-51199)},
+				},
+			},
+			{
+				name:         "flat interest, reduce emi",
+				interestType: interesttype.FLAT,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-41555), Interest: decimal.NewFromInt(-11000), Principal: decimal.NewFromInt(-30555)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-41563), Interest: decimal.NewFromInt(-11000), Principal: decimal.NewFromInt(-30563)},
+				},
+			},
+			{
+				name:         "flat interest, reduce tenure",
+				interestType: interesttype.FLAT,
+				update:       prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)}),
+				wantLen:      17,
+				wantRows: map[int]Row{
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-61000), Interest: decimal.NewFromInt(-11000), Principal: decimal.NewFromInt(-50000)},
+					17: {Period: 17, StartDate: timeParseUtil(t, "2021-08-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-09-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-60998), Interest: decimal.NewFromInt(-11000), Principal: decimal.NewFromInt(-49998)},
+				},
+			},
+			{
+				name:         "foreclosure, prepayment more than outstanding principal",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 22, Amount: decimal.NewFromInt(500000)}),
+				wantLen:      22,
+				wantRows: map[int]Row{
+					22: {Period: 22, StartDate: timeParseUtil(t, "2022-01-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-02-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-155523), Interest: decimal.NewFromInt(-3049), Principal: decimal.NewFromInt(-152474), Prepayment: decimal.NewFromInt(-102652)},
+				},
+			},
+			{
+				name:         "prepayment outside the schedule",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Date: getDate(2023, 1, 1), Amount: decimal.NewFromInt(200000)}),
+				wantErr:      ErrInvalidPrepayment,
+			},
+			{
+				name:         "negative prepayment",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 2, Amount: decimal.NewFromInt(-200000)}),
+				wantErr:      ErrInvalidPrepayment,
+			},
+			{
+				name:         "prepayment in the last period",
+				interestType: interesttype.REDUCING,
+				update:       prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 24, Amount: decimal.NewFromInt(5000)}),
+				wantErr:      ErrInvalidPrepayment,
+			},
+			{
+				name:         "prepayment after the tenure is reduced",
+				interestType: interesttype.REDUCING,
+				update: prepay(prepaymentstrategy.REDUCE_TENURE,
+					Prepayment{Period: 5, Amount: decimal.NewFromInt(200000)}, Prepayment{Period: 22, Amount: decimal.NewFromInt(5000)}),
+				wantErr: ErrInvalidPrepayment,
+			},
+			{
+				name:         "prepayment after a foreclosure",
+				interestType: interesttype.REDUCING,
+				update: prepay(prepaymentstrategy.REDUCE_EMI,
+					Prepayment{Period: 20, Amount: decimal.NewFromInt(500000)}, Prepayment{Period: 22, Amount: decimal.NewFromInt(5000)}),
+				wantErr: ErrInvalidPrepayment,
+			},
+		},
+		"day count": {
+			{
+				name:         "reducing interest, act/365 fixed",
+				interestType: interesttype.REDUCING,
+				update:       dayCount(daycount.ACT_365_FIXED),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-52886), Interest: decimal.NewFromInt(-19726), Principal: decimal.NewFromInt(-33160)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-52886), Interest: decimal.NewFromInt(-19707), Principal: decimal.NewFromInt(-33179)},
+					11: {Period: 11, StartDate: timeParseUtil(t, "2021-02-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-03-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52886), Interest: decimal.NewFromInt(-11799), Principal: decimal.NewFromInt(-41087)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52885), Interest: decimal.NewFromInt(-1056), Principal: decimal.NewFromInt(-51829)},
+				},
+			},
+			{
+				name:         "reducing interest, 30/360",
+				interestType: interesttype.REDUCING,
+				update:       dayCount(daycount.THIRTY_360),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32871)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+				},
+			},
+			{
+				name:         "flat interest, act/365 fixed",
+				interestType: interesttype.FLAT,
+				update:       dayCount(daycount.ACT_365_FIXED),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-19726), Principal: decimal.NewFromInt(-41941)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-20384), Principal: decimal.NewFromInt(-41283)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-61665), Interest: decimal.NewFromInt(-20384), Principal: decimal.NewFromInt(-41281)},
+				},
+			},
+		},
+		"broken period": {
+			{
+				name:         "broken period interest paid upfront",
+				interestType: interesttype.REDUCING,
+				update:       brokenPeriod(brokenperiod.UPFRONT),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					0:  {Type: rowtype.BROKEN_PERIOD, Period: 0, StartDate: brokenStart, EndDate: brokenEnd, Payment: decimal.NewFromInt(-12493), Interest: decimal.NewFromInt(-12493), Principal: decimal.Zero},
+					1:  {Type: rowtype.REGULAR, Period: 1, StartDate: brokenFirstStart, EndDate: brokenFirstEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32871)},
+					24: {Period: 24, StartDate: brokenLastStart, EndDate: brokenLastEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+				},
+			},
+			{
+				name:         "broken period interest added to the first installment",
+				interestType: interesttype.REDUCING,
+				update:       brokenPeriod(brokenperiod.ADD_TO_FIRST_INSTALLMENT),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					0:  {Type: rowtype.BROKEN_PERIOD, Period: 0, StartDate: brokenStart, EndDate: brokenEnd, Payment: decimal.Zero, Interest: decimal.Zero, Principal: decimal.Zero},
+					1:  {Period: 1, StartDate: brokenFirstStart, EndDate: brokenFirstEnd, Payment: decimal.NewFromInt(-65364), Interest: decimal.NewFromInt(-32493), Principal: decimal.NewFromInt(-32871)},
+					2:  {Period: 2, StartDate: timeParseUtil(t, "2020-06-06 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-05 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-19342), Principal: decimal.NewFromInt(-33529)},
+					24: {Period: 24, StartDate: brokenLastStart, EndDate: brokenLastEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+				},
+			},
+			{
+				name:         "broken period interest capitalised",
+				interestType: interesttype.REDUCING,
+				update:       brokenPeriod(brokenperiod.CAPITALISE),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					0:  {Type: rowtype.BROKEN_PERIOD, Period: 0, StartDate: brokenStart, EndDate: brokenEnd, Payment: decimal.Zero, Interest: decimal.NewFromInt(-12493), Principal: decimal.NewFromInt(12493)},
+					1:  {Period: 1, StartDate: brokenFirstStart, EndDate: brokenFirstEnd, Payment: decimal.NewFromInt(-53532), Interest: decimal.NewFromInt(-20250), Principal: decimal.NewFromInt(-33282)},
+					24: {Period: 24, StartDate: brokenLastStart, EndDate: brokenLastEnd, Payment: decimal.NewFromInt(-53531), Interest: decimal.NewFromInt(-1050), Principal: decimal.NewFromInt(-52481)},
+				},
+			},
+		},
+		"moratorium": {
+			{
+				name:         "reducing interest, capitalised",
+				interestType: interesttype.REDUCING,
+				update:       moratorium(Moratorium{Periods: 3, Type: moratoriumtype.CAPITALISE}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Type: rowtype.MORATORIUM, Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.Zero, Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(20000)},
+					3:  {Type: rowtype.MORATORIUM, Period: 3, StartDate: timeParseUtil(t, "2020-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.Zero, Interest: decimal.NewFromInt(-20808), Principal: decimal.NewFromInt(20808)},
+					4:  {Type: rowtype.REGULAR, Period: 4, StartDate: timeParseUtil(t, "2020-07-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-08-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-62383), Interest: decimal.NewFromInt(-21224), Principal: decimal.NewFromInt(-41159)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-62383), Interest: decimal.NewFromInt(-1223), Principal: decimal.NewFromInt(-61160)},
+				},
+			},
+			{
+				name:         "reducing interest, interest only",
+				interestType: interesttype.REDUCING,
+				update:       moratorium(Moratorium{Periods: 3, Type: moratoriumtype.INTEREST_ONLY}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Type: rowtype.MORATORIUM, Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-20000), Interest: decimal.NewFromInt(-20000), Principal: decimal.Zero},
+					3:  {Type: rowtype.MORATORIUM, Period: 3, StartDate: timeParseUtil(t, "2020-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-20000), Interest: decimal.NewFromInt(-20000), Principal: decimal.Zero},
+					4:  {Type: rowtype.REGULAR, Period: 4, StartDate: timeParseUtil(t, "2020-07-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-08-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-58785), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-38785)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-58785), Interest: decimal.NewFromInt(-1153), Principal: decimal.NewFromInt(-57632)},
+				},
+			},
+			{
+				name:         "flat interest, capitalised",
+				interestType: interesttype.FLAT,
+				update:       moratorium(Moratorium{Periods: 3}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					3:  {Type: rowtype.MORATORIUM, Period: 3, StartDate: timeParseUtil(t, "2020-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.Zero, Interest: decimal.NewFromInt(-20808), Principal: decimal.NewFromInt(20808)},
+					4:  {Period: 4, StartDate: timeParseUtil(t, "2020-07-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-08-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-71758), Interest: decimal.NewFromInt(-21224), Principal: decimal.NewFromInt(-50534)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-71752), Interest: decimal.NewFromInt(-21224), Principal: decimal.NewFromInt(-50528)},
+				},
+			},
+			{
+				name:         "moratorium over the whole tenure",
+				interestType: interesttype.REDUCING,
+				update:       moratorium(Moratorium{Periods: 24}),
+				wantErr:      ErrInvalidMoratorium,
+			},
+			{
+				name:         "negative moratorium",
+				interestType: interesttype.REDUCING,
+				update:       moratorium(Moratorium{Periods: -1}),
+				wantErr:      ErrInvalidMoratorium,
+			},
+		},
+		"rate reset": {
+			{
+				name:         "reducing interest, higher rate, keep tenure",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(3600, resetstrategy.KEEP_TENURE),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					6:  sixthRow,
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-57632), Interest: decimal.NewFromInt(-23779), Principal: decimal.NewFromInt(-33853)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-57632), Interest: decimal.NewFromInt(-1678), Principal: decimal.NewFromInt(-55954)},
+				},
+			},
+			{
+				name:         "reducing interest, higher rate, keep emi",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(3600, resetstrategy.KEEP_EMI),
+				wantLen:      27,
+				wantRows: map[int]Row{
+					6:  sixthRow,
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-51420), Interest: decimal.NewFromInt(-23779), Principal: decimal.NewFromInt(-27641)},
+					27: {Period: 27, StartDate: timeParseUtil(t, "2022-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-07-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-51419), Interest: decimal.NewFromInt(-1497), Principal: decimal.NewFromInt(-49922)},
+				},
+			},
+			{
+				name:         "reducing interest, lower rate, keep emi",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(600, resetstrategy.KEEP_EMI),
+				wantLen:      22,
+				wantRows: map[int]Row{
+					6:  sixthRow,
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-51672), Interest: decimal.NewFromInt(-3963), Principal: decimal.NewFromInt(-47709)},
+					22: {Period: 22, StartDate: timeParseUtil(t, "2022-01-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-02-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-51672), Interest: decimal.NewFromInt(-257), Principal: decimal.NewFromInt(-51415)},
+				},
+			},
+			{
+				name:         "reducing interest, zero rate, keep tenure",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(0, resetstrategy.KEEP_TENURE),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					6:  sixthRow,
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-44036), Interest: decimal.NewFromInt(0), Principal: decimal.NewFromInt(-44036)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-44033), Interest: decimal.NewFromInt(0), Principal: decimal.NewFromInt(-44033)},
+				},
+			},
+			{
+				name:         "reducing interest, zero rate, keep emi",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(0, resetstrategy.KEEP_EMI),
+				wantLen:      21,
+				wantRows: map[int]Row{
+					6:  sixthRow,
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52843), Interest: decimal.NewFromInt(0), Principal: decimal.NewFromInt(-52843)},
+					21: {Period: 21, StartDate: timeParseUtil(t, "2021-12-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-01-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52843), Interest: decimal.NewFromInt(0), Principal: decimal.NewFromInt(-52843)},
+				},
+			},
+			{
+				name:         "flat interest, lower rate, keep emi",
+				interestType: interesttype.FLAT,
+				update:       rateReset(1800, resetstrategy.KEEP_EMI),
+				wantLen:      21,
+				wantRows: map[int]Row{
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-61250), Interest: decimal.NewFromInt(-11250), Principal: decimal.NewFromInt(-50000)},
+					21: {Period: 21, StartDate: timeParseUtil(t, "2021-12-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-01-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-61248), Interest: decimal.NewFromInt(-11250), Principal: decimal.NewFromInt(-49998)},
+				},
+			},
+			{
+				name:         "payment does not cover the interest, keep emi",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(12000, resetstrategy.KEEP_EMI),
+				wantErr:      ErrInvalidRateReset,
+			},
+			{
+				name:         "reset after the last period starts",
+				interestType: interesttype.REDUCING,
+				update: combine(rateReset(3600, resetstrategy.KEEP_TENURE), func(config *Config) {
+					config.RateResets[0].Date = time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+				}),
+				wantErr: ErrInvalidRateReset,
+			},
+			{
+				name:         "negative rate",
+				interestType: interesttype.REDUCING,
+				update:       rateReset(-100, resetstrategy.KEEP_TENURE),
+				wantErr:      ErrInvalidRateReset,
+			},
+		},
+		"balloon": {
+			{
+				name:         "reducing interest with balloon",
+				interestType: interesttype.REDUCING,
+				update:       balloon(300000),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-43010), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-23010)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-343008), Interest: decimal.NewFromInt(-6726), Principal: decimal.NewFromInt(-336282)},
+				},
+			},
+			{
+				name:         "flat interest with balloon",
+				interestType: interesttype.FLAT,
+				update:       balloon(300000),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-49167), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-29167)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-349159), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-329159)},
+				},
+			},
+			{
+				name:         "bullet",
+				interestType: interesttype.BULLET,
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-20000), Interest: decimal.NewFromInt(-20000), Principal: decimal.Zero},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-1020000), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-1000000)},
+				},
+			},
+			{
+				name:         "balloon more than the amount borrowed",
+				interestType: interesttype.REDUCING,
+				update:       balloon(1000001),
+				wantErr:      ErrInvalidBalloonAmount,
+			},
+			{
+				name:         "negative balloon",
+				interestType: interesttype.REDUCING,
+				update:       balloon(-1),
+				wantErr:      ErrInvalidBalloonAmount,
+			},
+		},
+		"equal principal": {
+			{
+				name:         "payment at the end of a period",
+				interestType: interesttype.EQUAL_PRINCIPAL,
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-41667)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-60833), Interest: decimal.NewFromInt(-19166), Principal: decimal.NewFromInt(-41667)},
+					13: {Period: 13, StartDate: timeParseUtil(t, "2021-04-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-05-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-51667), Interest: decimal.NewFromInt(-10000), Principal: decimal.NewFromInt(-41667)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-42492), Interest: decimal.NewFromInt(-833), Principal: decimal.NewFromInt(-41659)},
+				},
+			},
+			{
+				name:         "payment at the beginning of a period",
+				interestType: interesttype.EQUAL_PRINCIPAL,
+				update:       beginning,
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-41667), Interest: decimal.Zero, Principal: decimal.NewFromInt(-41667)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-60833), Interest: decimal.NewFromInt(-19166), Principal: decimal.NewFromInt(-41667)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-42492), Interest: decimal.NewFromInt(-833), Principal: decimal.NewFromInt(-41659)},
+				},
+			},
+		},
+		"rule of 78": {
+			{
+				name:         "front loaded interest",
+				interestType: interesttype.RULE_OF_78,
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-38400), Principal: decimal.NewFromInt(-23267)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-36800), Principal: decimal.NewFromInt(-24867)},
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-61667), Interest: decimal.NewFromInt(-20800), Principal: decimal.NewFromInt(-40867)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-61659), Interest: decimal.NewFromInt(-1600), Principal: decimal.NewFromInt(-60059)},
+				},
+			},
+		},
+		"step": {
+			{
+				name:         "step up by percentage",
+				interestType: interesttype.REDUCING,
+				update:       step(Step{Periods: 12, Value: decimal.NewFromInt(10)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  row(1, -50639, -20000, -30639),
+					12: row(12, -50639, -12544, -38095),
+					13: row(13, -55702, -11781, -43921),
+					24: row(24, -55706, -1092, -54614),
+				},
+			},
+			{
+				name:         "step up by amount",
+				interestType: interesttype.REDUCING,
+				update:       step(Step{Periods: 12, Type: steptype.AMOUNT, Value: decimal.NewFromInt(5000)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  row(1, -50667, -20000, -30667),
+					13: row(13, -55667, -11774, -43893),
+					24: row(24, -55667, -1092, -54575),
+				},
+			},
+			{
+				name:         "step down by percentage",
+				interestType: interesttype.REDUCING,
+				update:       step(Step{Periods: 6, Value: decimal.NewFromInt(-10)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  row(1, -60553, -20000, -40553),
+					7:  row(7, -54497, -14883, -39614),
+					13: row(13, -49048, -9886, -39162),
+					24: row(24, -44141, -866, -43275),
+				},
+			},
+			{
+				name:         "payment at the beginning of a period",
+				interestType: interesttype.REDUCING,
+				update:       combine(step(Step{Periods: 12, Value: decimal.NewFromInt(10)}), beginning),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  row(1, -49646, 0, -49646),
+					2:  row(2, -49646, -19007, -30639),
+					13: row(13, -54610, -11550, -43060),
+					24: row(24, -54613, -1071, -53542),
+				},
+			},
+			{
+				name:         "step down leaving a -ve emi",
+				interestType: interesttype.REDUCING,
+				update:       step(Step{Periods: 6, Type: steptype.AMOUNT, Value: decimal.NewFromInt(-40000)}),
+				wantErr:      ErrInvalidStep,
+			},
+			{
+				name:         "step for flat interest",
+				interestType: interesttype.FLAT,
+				update:       step(Step{Periods: 12, Value: decimal.NewFromInt(10)}),
+				wantErr:      ErrInvalidStep,
+			},
+		},
+		"compounding": {
+			{
+				name:         "compounded annually",
+				interestType: interesttype.REDUCING,
+				update:       compounding(frequency.ANNUALLY),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-51733), Interest: decimal.NewFromInt(-18088), Principal: decimal.NewFromInt(-33645)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-51731), Interest: decimal.NewFromInt(-919), Principal: decimal.NewFromInt(-50812)},
+				},
+			},
+			{
+				name:         "compounded daily",
+				interestType: interesttype.REDUCING,
+				update:       compounding(frequency.DAILY),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-52988), Interest: decimal.NewFromInt(-20195), Principal: decimal.NewFromInt(-32793)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52987), Interest: decimal.NewFromInt(-1049), Principal: decimal.NewFromInt(-51938)},
+				},
+			},
+			{
+				name:         "flat interest",
+				interestType: interesttype.FLAT,
+				update:       compounding(frequency.HALF_YEARLY),
+				wantErr:      ErrInvalidFrequency,
+			},
+		},
+		"frequency": {
+			{
+				name:         "biweekly",
+				interestType: interesttype.REDUCING,
+				update:       schedule(frequency.BIWEEKLY, getDate(2020, 1, 1), getDate(2020, 12, 15)),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-01-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-44976), Interest: decimal.NewFromInt(-9231), Principal: decimal.NewFromInt(-35745)},
+					25: {Period: 25, StartDate: timeParseUtil(t, "2020-12-02 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-12-15 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-44976), Interest: decimal.NewFromInt(-411), Principal: decimal.NewFromInt(-44565)},
+				},
+			},
+			{
+				name:         "semi-monthly",
+				interestType: interesttype.REDUCING,
+				update:       schedule(frequency.SEMI_MONTHLY, getDate(2020, 1, 15), getDate(2020, 12, 31)),
+				wantLen:      23,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-01-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-48886), Interest: decimal.NewFromInt(-10000), Principal: decimal.NewFromInt(-38886)},
+					2:  {Period: 2, StartDate: timeParseUtil(t, "2020-02-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-02-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-48886), Interest: decimal.NewFromInt(-9611), Principal: decimal.NewFromInt(-39275)},
+					23: {Period: 23, StartDate: timeParseUtil(t, "2020-12-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-12-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-48885), Interest: decimal.NewFromInt(-484), Principal: decimal.NewFromInt(-48401)},
+				},
+			},
+			{
+				name:         "quarterly",
+				interestType: interesttype.REDUCING,
+				update:       schedule(frequency.QUARTERLY, getDate(2020, 1, 1), getDate(2022, 12, 31)),
+				wantLen:      12,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-119277), Interest: decimal.NewFromInt(-60000), Principal: decimal.NewFromInt(-59277)},
+					12: {Period: 12, StartDate: timeParseUtil(t, "2022-10-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-12-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-119276), Interest: decimal.NewFromInt(-6752), Principal: decimal.NewFromInt(-112524)},
+				},
+			},
+			{
+				name:         "half yearly",
+				interestType: interesttype.REDUCING,
+				update:       schedule(frequency.HALF_YEARLY, getDate(2020, 1, 1), getDate(2024, 12, 31)),
+				wantLen:      10,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-06-30 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-176984), Interest: decimal.NewFromInt(-120000), Principal: decimal.NewFromInt(-56984)},
+					10: {Period: 10, StartDate: timeParseUtil(t, "2024-07-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2024-12-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-176982), Interest: decimal.NewFromInt(-18962), Principal: decimal.NewFromInt(-158020)},
+				},
+			},
+		},
+		"business day": {
+			{
+				name:         "following, interest unchanged",
+				interestType: interesttype.REDUCING,
+				update:       businessDay(businessday.FOLLOWING, false),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: timeParseUtil(t, "2020-05-15 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32871)},
+					2:  {Period: 2, StartDate: timeParseUtil(t, "2020-05-16 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-06-15 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-19342), Principal: decimal.NewFromInt(-33529)},
+					3:  {Period: 3, StartDate: timeParseUtil(t, "2020-06-16 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-18672), Principal: decimal.NewFromInt(-34199)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+				},
+			},
+			{
+				name:         "following, accruing on adjusted dates",
+				interestType: interesttype.REDUCING,
+				update:       combine(businessDay(businessday.FOLLOWING, true), dayCount(daycount.ACT_365_FIXED)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: timeParseUtil(t, "2020-05-15 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52899), Interest: decimal.NewFromInt(-20383), Principal: decimal.NewFromInt(-32516)},
+					3:  {Period: 3, StartDate: timeParseUtil(t, "2020-06-16 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52899), Interest: decimal.NewFromInt(-17815), Principal: decimal.NewFromInt(-35084)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52898), Interest: decimal.NewFromInt(-1056), Principal: decimal.NewFromInt(-51842)},
+				},
+			},
+			{
+				name:         "preceding, accruing on adjusted dates",
+				interestType: interesttype.REDUCING,
+				update:       combine(businessDay(businessday.PRECEDING, true), dayCount(daycount.ACT_365_FIXED)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: timeParseUtil(t, "2020-05-13 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-19069), Principal: decimal.NewFromInt(-33801)},
+					11: {Period: 11, StartDate: timeParseUtil(t, "2021-02-13 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-03-12 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-11784), Principal: decimal.NewFromInt(-41086)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52872), Interest: decimal.NewFromInt(-1057), Principal: decimal.NewFromInt(-51815)},
+				},
+			},
+		},
+		"due day anchor": {
+			{
+				name:         "fixed day, starting on the 31st",
+				interestType: interesttype.REDUCING,
+				update:       dueDayAnchor(dueday.FIXED_DAY, getDate(2020, 12, 30)),
+				wantLen:      11,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-31 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102173), Interest: decimal.NewFromInt(-19726), Principal: decimal.NewFromInt(-82447)},
+					2:  {Period: 2, StartDate: timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-03-30 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102173), Interest: decimal.NewFromInt(-18100), Principal: decimal.NewFromInt(-84073)},
+					11: {Period: 11, StartDate: timeParseUtil(t, "2020-12-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-12-30 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102173), Interest: decimal.NewFromInt(-1976), Principal: decimal.NewFromInt(-100197)},
+				},
+			},
+			{
+				name:         "last day, starting on the 31st",
+				interestType: interesttype.REDUCING,
+				update:       dueDayAnchor(dueday.LAST_DAY, getDate(2020, 12, 31)),
+				wantLen:      11,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: timeParseUtil(t, "2020-01-31 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102209), Interest: decimal.NewFromInt(-19726), Principal: decimal.NewFromInt(-82483)},
+					2:  {Period: 2, StartDate: timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102209), Interest: decimal.NewFromInt(-18703), Principal: decimal.NewFromInt(-83506)},
+					11: {Period: 11, StartDate: timeParseUtil(t, "2020-12-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-12-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-102209), Interest: decimal.NewFromInt(-2042), Principal: decimal.NewFromInt(-100167)},
+				},
+			},
+		},
+		"rounding mode": {
+			{
+				name:         "up",
+				interestType: interesttype.REDUCING,
+				update:       rounding(roundingmode.UP, decimal.Zero),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-52872), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32872)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-52872), Interest: decimal.NewFromInt(-19343), Principal: decimal.NewFromInt(-33529)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52858), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51821)},
+				},
+			},
+			{
+				name:         "down",
+				interestType: interesttype.REDUCING,
+				update:       rounding(roundingmode.DOWN, decimal.Zero),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-19343), Principal: decimal.NewFromInt(-33528)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52881), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51844)},
+				},
+			},
+			{
+				name:         "up to the next 10",
+				interestType: interesttype.REDUCING,
+				update:       rounding(roundingmode.UP, decimal.NewFromInt(10)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-52880), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32880)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-52880), Interest: decimal.NewFromInt(-19350), Principal: decimal.NewFromInt(-33530)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-52760), Interest: decimal.NewFromInt(-1040), Principal: decimal.NewFromInt(-51720)},
+				},
+			},
+		},
+		"cross-feature": {
+			{
+				name:         "prepayment, act/365 fixed",
+				interestType: interesttype.REDUCING,
+				update:       combine(prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)}), dayCount(daycount.ACT_365_FIXED)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					6:  {Period: 6, StartDate: timeParseUtil(t, "2020-09-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-10-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252886), Interest: decimal.NewFromInt(-16360), Principal: decimal.NewFromInt(-236526), Prepayment: decimal.NewFromInt(-200000)},
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-39545), Interest: decimal.NewFromInt(-12084), Principal: decimal.NewFromInt(-27461)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-39547), Interest: decimal.NewFromInt(-790), Principal: decimal.NewFromInt(-38757)},
+				},
+			},
+			{
+				name:         "prepayment, capitalised moratorium, reduce tenure",
+				interestType: interesttype.REDUCING,
+				update: combine(prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)}),
+					moratorium(Moratorium{Periods: 3, Type: moratoriumtype.CAPITALISE})),
+				wantLen: 20,
+				wantRows: map[int]Row{
+					3:  {Type: rowtype.MORATORIUM, Period: 3, StartDate: timeParseUtil(t, "2020-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.Zero, Interest: decimal.NewFromInt(-20808), Principal: decimal.NewFromInt(20808)},
+					6:  {Period: 6, StartDate: timeParseUtil(t, "2020-09-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-10-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-262383), Interest: decimal.NewFromInt(-19561), Principal: decimal.NewFromInt(-242822), Prepayment: decimal.NewFromInt(-200000)},
+					7:  {Period: 7, StartDate: timeParseUtil(t, "2020-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-60733), Interest: decimal.NewFromInt(-14705), Principal: decimal.NewFromInt(-46028)},
+					20: {Period: 20, StartDate: timeParseUtil(t, "2021-11-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-12-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-60734), Interest: decimal.NewFromInt(-1191), Principal: decimal.NewFromInt(-59543)},
+				},
+			},
+			{
+				name:         "prepayment, flat interest, rate reset",
+				interestType: interesttype.FLAT,
+				update:       combine(prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 12, Amount: decimal.NewFromInt(200000)}), rateReset(1800, resetstrategy.KEEP_TENURE)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					7:  row(7, -52917, -11250, -41667),
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252917), Interest: decimal.NewFromInt(-11250), Principal: decimal.NewFromInt(-241667), Prepayment: decimal.NewFromInt(-200000)},
+					13: row(13, -29500, -4500, -25000),
+					24: row(24, -29496, -4500, -24996),
+				},
+			},
+			{
+				name:         "prepayment, equal principal, act/365 fixed",
+				interestType: interesttype.EQUAL_PRINCIPAL,
+				update:       combine(prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 12, Amount: decimal.NewFromInt(200000)}), dayCount(daycount.ACT_365_FIXED)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252708), Interest: decimal.NewFromInt(-11041), Principal: decimal.NewFromInt(-241667), Prepayment: decimal.NewFromInt(-200000)},
+					13: row(13, -30917, -5917, -25000),
+					24: row(24, -25505, -509, -24996),
+				},
+			},
+			{
+				name:         "prepayment, balloon, reduce tenure",
+				interestType: interesttype.REDUCING,
+				update:       combine(prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Period: 12, Amount: decimal.NewFromInt(200000)}), balloon(300000)),
+				wantLen:      18,
+				wantRows: map[int]Row{
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-243010), Interest: decimal.NewFromInt(-14400), Principal: decimal.NewFromInt(-228610), Prepayment: decimal.NewFromInt(-200000)},
+					13: row(13, -40168, -9828, -30340),
+					18: row(18, -340168, -6670, -333498),
+				},
+			},
+			{
+				name:         "prepayment during moratorium",
+				interestType: interesttype.REDUCING,
+				update:       combine(prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 2, Amount: decimal.NewFromInt(100000)}), moratorium(Moratorium{Periods: 3})),
+				wantErr:      ErrInvalidPrepayment,
+			},
+			{
+				name:         "step, rate reset",
+				interestType: interesttype.REDUCING,
+				update:       combine(step(Step{Periods: 12, Value: decimal.NewFromInt(10)}), rateReset(3600, resetstrategy.KEEP_TENURE)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					6:  row(6, -50639, -16812, -33827),
+					7:  row(7, -55304, -24202, -31102),
+					13: row(13, -60835, -18167, -42668),
+					24: row(24, -60836, -1772, -59064),
+				},
+			},
+			{
+				name:         "step, prepayment, reduce emi",
+				interestType: interesttype.REDUCING,
+				update:       combine(step(Step{Periods: 12, Value: decimal.NewFromInt(10)}), prepay(prepaymentstrategy.REDUCE_EMI, Prepayment{Period: 6, Amount: decimal.NewFromInt(200000)})),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					7:  row(7, -38085, -12135, -25950),
+					12: row(12, -38085, -9434, -28651),
+					13: row(13, -41893, -8861, -33032),
+					24: row(24, -41894, -821, -41073),
+				},
+			},
+			{
+				name:         "step, following business day, accruing on adjusted dates",
+				interestType: interesttype.REDUCING,
+				update: combine(step(Step{Periods: 12, Value: decimal.NewFromInt(10)}), businessDay(businessday.FOLLOWING, true),
+					dayCount(daycount.ACT_365_FIXED)),
+				wantLen: 24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: timeParseUtil(t, "2020-05-15 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-50666), Interest: decimal.NewFromInt(-20384), Principal: decimal.NewFromInt(-30282)},
+					13: row(13, -55732, -11621, -44111),
+					24: row(24, -55732, -1113, -54619),
+				},
+			},
+			{
+				name:         "capitalised moratorium, compounded annually",
+				interestType: interesttype.REDUCING,
+				update:       combine(moratorium(Moratorium{Periods: 3, Type: moratoriumtype.CAPITALISE}), compounding(frequency.ANNUALLY)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Type: rowtype.MORATORIUM, Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.Zero, Interest: decimal.NewFromInt(-18088), Principal: decimal.NewFromInt(18088)},
+					4:  row(4, -60844, -19087, -41757),
+					24: row(24, -60844, -1081, -59763),
+				},
+			},
+			{
+				name:         "capitalised broken period interest, interest only moratorium",
+				interestType: interesttype.REDUCING,
+				update:       combine(brokenPeriod(brokenperiod.CAPITALISE), moratorium(Moratorium{Periods: 3, Type: moratoriumtype.INTEREST_ONLY})),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					0:  {Type: rowtype.BROKEN_PERIOD, Period: 0, StartDate: brokenStart, EndDate: brokenEnd, Payment: decimal.Zero, Interest: decimal.NewFromInt(-12493), Principal: decimal.NewFromInt(12493)},
+					1:  {Type: rowtype.MORATORIUM, Period: 1, StartDate: brokenFirstStart, EndDate: brokenFirstEnd, Payment: decimal.NewFromInt(-20250), Interest: decimal.NewFromInt(-20250), Principal: decimal.Zero},
+					4:  {Type: rowtype.REGULAR, Period: 4, StartDate: timeParseUtil(t, "2020-08-06 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-09-05 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-59519), Interest: decimal.NewFromInt(-20250), Principal: decimal.NewFromInt(-39269)},
+					24: {Period: 24, StartDate: brokenLastStart, EndDate: brokenLastEnd, Payment: decimal.NewFromInt(-59521), Interest: decimal.NewFromInt(-1167), Principal: decimal.NewFromInt(-58354)},
+				},
+			},
+			{
+				name:         "bullet, act/365 fixed",
+				interestType: interesttype.BULLET,
+				update:       dayCount(daycount.ACT_365_FIXED),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-19726), Interest: decimal.NewFromInt(-19726), Principal: decimal.Zero},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-20384), Interest: decimal.NewFromInt(-20384), Principal: decimal.Zero},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-1020384), Interest: decimal.NewFromInt(-20384), Principal: decimal.NewFromInt(-1000000)},
+				},
+			},
+			{
+				name:         "bullet, prepayment, tenure unchanged",
+				interestType: interesttype.BULLET,
+				update:       prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Period: 12, Amount: decimal.NewFromInt(400000)}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-420000), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-400000), Prepayment: decimal.NewFromInt(-400000)},
+					13: {Period: 13, StartDate: timeParseUtil(t, "2021-04-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-05-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-12000), Interest: decimal.NewFromInt(-12000), Principal: decimal.Zero},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-612000), Interest: decimal.NewFromInt(-12000), Principal: decimal.NewFromInt(-600000)},
+				},
+			},
+			{
+				name:         "bullet, capitalised moratorium",
+				interestType: interesttype.BULLET,
+				update:       moratorium(Moratorium{Periods: 3, Type: moratoriumtype.CAPITALISE}),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					3:  {Type: rowtype.MORATORIUM, Period: 3, StartDate: timeParseUtil(t, "2020-06-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC"), Payment: decimal.Zero, Interest: decimal.NewFromInt(-20808), Principal: decimal.NewFromInt(20808)},
+					4:  {Type: rowtype.REGULAR, Period: 4, StartDate: timeParseUtil(t, "2020-07-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-08-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-21224), Interest: decimal.NewFromInt(-21224), Principal: decimal.Zero},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-1082432), Interest: decimal.NewFromInt(-21224), Principal: decimal.NewFromInt(-1061208)},
+				},
+			},
+			{
+				name:         "bullet, capitalised broken period interest",
+				interestType: interesttype.BULLET,
+				update:       brokenPeriod(brokenperiod.CAPITALISE),
+				wantLen:      25,
+				wantRows: map[int]Row{
+					0:  {Type: rowtype.BROKEN_PERIOD, Period: 0, StartDate: brokenStart, EndDate: brokenEnd, Payment: decimal.Zero, Interest: decimal.NewFromInt(-12493), Principal: decimal.NewFromInt(12493)},
+					1:  {Period: 1, StartDate: brokenFirstStart, EndDate: brokenFirstEnd, Payment: decimal.NewFromInt(-20250), Interest: decimal.NewFromInt(-20250), Principal: decimal.Zero},
+					24: {Period: 24, StartDate: brokenLastStart, EndDate: brokenLastEnd, Payment: decimal.NewFromInt(-1032743), Interest: decimal.NewFromInt(-20250), Principal: decimal.NewFromInt(-1012493)},
+				},
+			},
+			{
+				name:         "equal principal, act/365 fixed",
+				interestType: interesttype.EQUAL_PRINCIPAL,
+				update:       dayCount(daycount.ACT_365_FIXED),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-61393), Interest: decimal.NewFromInt(-19726), Principal: decimal.NewFromInt(-41667)},
+					2:  {Period: 2, StartDate: secondStart, EndDate: secondEnd, Payment: decimal.NewFromInt(-61201), Interest: decimal.NewFromInt(-19534), Principal: decimal.NewFromInt(-41667)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-42508), Interest: decimal.NewFromInt(-849), Principal: decimal.NewFromInt(-41659)},
+				},
+			},
+			{
+				name:         "equal principal, prepayment, reduce tenure",
+				interestType: interesttype.EQUAL_PRINCIPAL,
+				update:       prepay(prepaymentstrategy.REDUCE_TENURE, Prepayment{Period: 12, Amount: decimal.NewFromInt(200000)}),
+				wantLen:      19,
+				wantRows: map[int]Row{
+					12: {Period: 12, StartDate: timeParseUtil(t, "2021-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-252500), Interest: decimal.NewFromInt(-10833), Principal: decimal.NewFromInt(-241667), Prepayment: decimal.NewFromInt(-200000)},
+					13: {Period: 13, StartDate: timeParseUtil(t, "2021-04-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-05-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-48856), Interest: decimal.NewFromInt(-5999), Principal: decimal.NewFromInt(-42857)},
+					19: {Period: 19, StartDate: timeParseUtil(t, "2021-10-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-11-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-43711), Interest: decimal.NewFromInt(-857), Principal: decimal.NewFromInt(-42854)},
+				},
+			},
+			{
+				name:         "compounded annually, act/365 fixed",
+				interestType: interesttype.REDUCING,
+				update:       combine(compounding(frequency.ANNUALLY), dayCount(daycount.ACT_365_FIXED)),
+				wantLen:      24,
+				wantRows: map[int]Row{
+					1:  {Period: 1, StartDate: firstStart, EndDate: firstEnd, Payment: decimal.NewFromInt(-51746), Interest: decimal.NewFromInt(-17837), Principal: decimal.NewFromInt(-33909)},
+					24: {Period: 24, StartDate: lastStart, EndDate: lastEnd, Payment: decimal.NewFromInt(-51744), Interest: decimal.NewFromInt(-937), Principal: decimal.NewFromInt(-50807)},
+				},
+			},
+		},
+	}
+	features := make([]string, 0, len(tests))
+	for feature := range tests {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	for _, feature := range features {
+		t.Run(feature, func(t *testing.T) {
+			for _, tt := range tests[feature] {
+				t.Run(tt.name, func(t *testing.T) {
+					config := getConfigDto(frequency.MONTHLY, true, tt.interestType, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+					if tt.update != nil {
+						tt.update(config)
+					}
+					verifyTable(t, config, tt.wantLen, tt.wantRows, tt.wantErr)
+					// rate resets are applied to a copy of the config.
+					if !config.Interest.Equal(decimal.NewFromInt(2400)) {
+						t.Fatalf("config interest changed to %v", config.Interest)
+					}
+				})
+			}
+		})
+	}
+}
+
+func Test_amortization_GenerateTable_DayCountLongTenure(t *testing.T) {
+	for _, convention := range []daycount.Type{daycount.ACT_360, daycount.ACT_365_FIXED} {
+		t.Run(convention.String(), func(t *testing.T) {
+			config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(1200), 0)
+			config.EndDate = time.Date(2040, 4, 14, 0, 0, 0, 0, time.UTC)
+			config.DayCountConvention = convention
+			a, err := NewAmortization(config)
+			if err != nil {
+				t.Fatalf("NewAmortization() call failed. error = %v", err)
+			}
+			got, err := a.GenerateTable()
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			if len(got) != 240 {
+				t.Fatalf("length mismatch of rows generate, want=%v, got=%v", 240, len(got))
+			}
+			// the last payment differs from the emi only by the residual principal of rounding and one unit.
+			emi, last := got[0].Payment, got[len(got)-1]
+			if diff := last.Payment.Sub(last.Residual).Sub(emi).Abs(); diff.GreaterThan(decimal.NewFromInt(1)) {
+				t.Fatalf("last payment %v(residual %v) differs from the emi %v by %v", last.Payment, last.Residual, emi, diff)
+			}
+			if err := balanceCheck(t, got, config.AmountBorrowed); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func Test_amortization_GenerateTable_StepLongTenure(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(1200), 0)
+	config.EndDate = time.Date(2050, 4, 14, 0, 0, 0, 0, time.UTC)
+	config.Step = Step{Periods: 12, Value: decimal.NewFromInt(5)}
+	a, err := NewAmortization(config)
+	if err != nil {
+		t.Fatalf("NewAmortization() call failed. error = %v", err)
+	}
+	got, err := a.GenerateTable()
+	if err != nil {
+		t.Fatalf("GenerateTable() error = %v", err)
+	}
+	if len(got) != 360 {
+		t.Fatalf("length mismatch of rows generate, want=%v, got=%v", 360, len(got))
+	}
+	// the emi steps up by 5% every year, and is level within the year.
+	emi := a.Financial.GetPayment(*config).Neg()
+	for _, period := range []int{1, 12, 13, 120, 359} {
+		want := config.round(config.getSteppedEmi(emi, int64(period)).Neg())
+		if !got[period-1].Payment.Equal(want) {
+			t.Fatalf("payment mismatch in period %d, want=%v, got=%v", period, want, got[period-1].Payment)
+		}
+	}
+	if err := balanceCheck(t, got, config.AmountBorrowed); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_amortization_GenerateTable_ZeroInterest(t *testing.T) {
+	tests := []struct {
+		name         string
+		interestType interesttype.Type
+		update       func(config *Config)
+	}{
+		{name: "reducing interest", interestType: interesttype.REDUCING},
+		{name: "reducing interest, beginning", interestType: interesttype.REDUCING, update: func(config *Config) { config.PaymentPeriod = paymentperiod.BEGINNING }},
+		{name: "reducing interest, day count", interestType: interesttype.REDUCING, update: func(config *Config) { config.DayCountConvention = daycount.ACT_365_FIXED }},
+		{name: "reducing interest, step", interestType: interesttype.REDUCING, update: func(config *Config) { config.Step = Step{Periods: 6, Value: decimal.NewFromInt(10)} }},
+		{name: "reducing interest, compounding", interestType: interesttype.REDUCING, update: func(config *Config) { config.CompoundingFrequency = frequency.DAILY }},
+		{name: "flat interest", interestType: interesttype.FLAT},
+		{name: "equal principal", interestType: interesttype.EQUAL_PRINCIPAL},
+		{name: "rule of 78", interestType: interesttype.RULE_OF_78},
+		{name: "bullet", interestType: interesttype.BULLET},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getConfigDto(frequency.MONTHLY, true, tt.interestType, decimal.NewFromInt(1000000), decimal.Zero, 0)
+			if tt.update != nil {
+				tt.update(config)
+			}
+			a, err := NewAmortization(config)
+			if err != nil {
+				t.Fatalf("NewAmortization() call failed. error = %v", err)
+			}
+			got, err := a.GenerateTable()
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			for _, row := range got {
+				if !row.Interest.IsZero() {
+					t.Fatalf("interest in period %d, want=0, got=%v", row.Period, row.Interest)
+				}
+			}
+			if err := balanceCheck(t, got, config.AmountBorrowed); err != nil {
+				t.Fatal(err)
+			}
 		})
 	}
 }
 
+func TestNewAmortizationWithTenure(t *testing.T) {
+	getConfig := func(firstDueDate time.Time, anchor dueday.Type) *Config {
+		config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+		config.EndDate = time.Time{}
+		config.FirstDueDate = firstDueDate
+		config.DueDayAnchor = anchor
+		return config
+	}
+	tests := []struct {
+		name        string
+		config      *Config
+		tenure      int64
+		wantErr     error
+		wantEndDate time.Time
+		wantLen     int
+		wantRows    map[int]Row
+	}{
+		{
+			name:        "monthly",
+			config:      getConfig(time.Time{}, 0),
+			tenure:      24,
+			wantEndDate: getDate(2022, 4, 14),
+			wantLen:     24,
+			wantRows: map[int]Row{
+				1:  {Period: 1, StartDate: timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-05-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32871)},
+				24: {Period: 24, StartDate: timeParseUtil(t, "2022-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+			},
+		},
+		{
+			name:        "broken period before the first due date",
+			config:      getConfig(getDate(2020, 6, 5), 0),
+			tenure:      12,
+			wantEndDate: getDate(2021, 5, 5),
+			wantLen:     13,
+			wantRows: map[int]Row{
+				1:  {Period: 1, StartDate: timeParseUtil(t, "2020-05-06 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-06-05 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-94560), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-74560)},
+				12: {Period: 12, StartDate: timeParseUtil(t, "2021-04-06 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2021-05-05 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-94559), Interest: decimal.NewFromInt(-1855), Principal: decimal.NewFromInt(-92704)},
+			},
+		},
+		{
+			name:        "due on the last day of the month",
+			config:      getConfig(time.Time{}, dueday.LAST_DAY),
+			tenure:      3,
+			wantEndDate: getDate(2020, 7, 31),
+			wantLen:     3,
+			wantRows: map[int]Row{
+				1: {Period: 1, StartDate: timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-05-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-346755), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-326755)},
+				3: {Period: 3, StartDate: timeParseUtil(t, "2020-07-01 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2020-07-31 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-346754), Interest: decimal.NewFromInt(-6799), Principal: decimal.NewFromInt(-339955)},
+			},
+		},
+		{
+			name:    "no periods",
+			config:  getConfig(time.Time{}, 0),
+			tenure:  0,
+			wantErr: ErrInvalidTenure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAmortizationWithTenure(tt.config, tt.tenure)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewAmortizationWithTenure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !tt.config.EndDate.Equal(tt.wantEndDate) {
+				t.Fatalf("end date mismatch, want=%v, got=%v", tt.wantEndDate, tt.config.EndDate)
+			}
+			got, err := a.GenerateTable()
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("length mismatch of rows generated, want=%v, got=%v", tt.wantLen, len(got))
+			}
+			rows := make(map[int]Row)
+			for _, row := range got {
+				rows[int(row.Period)] = row
+			}
+			for period, want := range tt.wantRows {
+				if err := verifyRow(t, rows[period], want); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAmortizationWithTenure_ReusedConfig(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	config.EndDate = time.Time{}
+	if _, err := NewAmortizationWithTenure(config, 12); err != nil {
+		t.Fatalf("NewAmortizationWithTenure() call failed. error = %v", err)
+	}
+	// the tenure is not kept on the config, so a later NewAmortization on a copy of it follows the end date of the copy.
+	copied := *config
+	copied.EndDate = getDate(2022, 4, 14)
+	verifyTable(t, &copied, 24, map[int]Row{
+		24: {Period: 24, StartDate: timeParseUtil(t, "2022-03-15 00:00:00 +0000 UTC"), EndDate: timeParseUtil(t, "2022-04-14 23:59:59 +0000 UTC"), Payment: decimal.NewFromInt(-52871), Interest: decimal.NewFromInt(-1037), Principal: decimal.NewFromInt(-51834)},
+	}, nil)
+	config.EndDate = time.Time{}
+	if err := config.Validate(); !errors.Is(err, ErrInvalidDate) {
+		t.Fatalf("Validate() error = %v, wantErr %v", err, ErrInvalidDate)
+	}
+}
+
+func Test_amortization_GenerateTable_ResidualAllocation(t *testing.T) {
+	// EMI of 52871.0972 rounded to the nearest 10 rupees leaves a residual of -20, i.e. 20 more to be collected.
+	getConfig := func(allocation residualallocation.Type, mode roundingmode.Type) *Config {
+		config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+		config.RoundingIncrement = decimal.NewFromInt(10)
+		config.RoundingMode = mode
+		config.ResidualAllocation = allocation
+		return config
+	}
+	tests := []struct {
+		name         string
+		config       *Config
+		wantResidual decimal.Decimal
+		wantRows     map[int]Row
+	}{
+		{
+			name:         "adjust last installment",
+			config:       getConfig(0, 0),
+			wantResidual: decimal.NewFromInt(-20),
+			wantRows: map[int]Row{
+				1:  {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32870)},
+				24: {Payment: decimal.NewFromInt(-52890), Interest: decimal.NewFromInt(-1040), Principal: decimal.NewFromInt(-51850), Residual: decimal.NewFromInt(-20)},
+			},
+		},
+		{
+			name:         "adjust first installment",
+			config:       getConfig(residualallocation.ADJUST_FIRST_INSTALLMENT, 0),
+			wantResidual: decimal.NewFromInt(-20),
+			wantRows: map[int]Row{
+				1:  {Payment: decimal.NewFromInt(-52890), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32890), Residual: decimal.NewFromInt(-20)},
+				2:  {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-19340), Principal: decimal.NewFromInt(-33530)},
+				24: {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-1040), Principal: decimal.NewFromInt(-51830)},
+			},
+		},
+		{
+			name:         "spread",
+			config:       getConfig(residualallocation.SPREAD, 0),
+			wantResidual: decimal.NewFromInt(-20),
+			wantRows: map[int]Row{
+				1:  {Payment: decimal.NewFromInt(-52880), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32880), Residual: decimal.NewFromInt(-10)},
+				2:  {Payment: decimal.NewFromInt(-52880), Interest: decimal.NewFromInt(-19340), Principal: decimal.NewFromInt(-33540), Residual: decimal.NewFromInt(-10)},
+				3:  {Payment: decimal.NewFromInt(-52870)},
+				24: {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-1040), Principal: decimal.NewFromInt(-51830)},
+			},
+		},
+		{
+			name:         "spread, rounded up",
+			config:       getConfig(residualallocation.SPREAD, roundingmode.UP),
+			wantResidual: decimal.NewFromInt(120),
+			wantRows: map[int]Row{
+				1:  {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32870), Residual: decimal.NewFromInt(10)},
+				12: {Payment: decimal.NewFromInt(-52870), Residual: decimal.NewFromInt(10)},
+				13: {Payment: decimal.NewFromInt(-52880)},
+				24: {Payment: decimal.NewFromInt(-52880)},
+			},
+		},
+		{
+			name:         "adjust interest",
+			config:       getConfig(residualallocation.ADJUST_INTEREST, 0),
+			wantResidual: decimal.NewFromInt(-20),
+			wantRows: map[int]Row{
+				1:  {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-20000), Principal: decimal.NewFromInt(-32870)},
+				24: {Payment: decimal.NewFromInt(-52870), Interest: decimal.NewFromInt(-1020), Principal: decimal.NewFromInt(-51850), Residual: decimal.NewFromInt(-20)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAmortization(tt.config)
+			if err != nil {
+				t.Fatalf("NewAmortization() call failed. error = %v", err)
+			}
+			got, err := a.GenerateTable()
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			if len(got) != 24 {
+				t.Fatalf("length mismatch of rows generate, want=%v, got=%v", 24, len(got))
+			}
+			for period, want := range tt.wantRows {
+				row := got[period-1]
+				if !row.Payment.Equal(want.Payment) || !row.Residual.Equal(want.Residual) {
+					t.Fatalf("payment or residual mismatch in period %d, want=%v %v, got=%v %v", period, want.Payment, want.Residual, row.Payment, row.Residual)
+				}
+				if !want.Principal.IsZero() && (!row.Principal.Equal(want.Principal) || !row.Interest.Equal(want.Interest)) {
+					t.Fatalf("principal or interest mismatch in period %d, want=%v %v, got=%v %v", period, want.Principal, want.Interest, row.Principal, row.Interest)
+				}
+			}
+			if residual := GetResidual(got); !residual.Equal(tt.wantResidual) {
+				t.Fatalf("GetResidual() = %v, want %v", residual, tt.wantResidual)
+			}
+			if err := principalCheck(t, got, tt.config.AmountBorrowed); err != nil {
+				t.Fatal(err)
+			}
+			if err := balanceCheck(t, got, tt.config.AmountBorrowed); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// verifyTable generates the table for a config and verifies the number of rows, the given rows(by period) and
+// the principal and balance totals. The wanted error may be returned by NewAmortization or by GenerateTable.
+func verifyTable(t *testing.T, config *Config, wantLen int, wantRows map[int]Row, wantErr error) {
+	a, err := NewAmortization(config)
+	if err != nil {
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("NewAmortization() error = %v, wantErr %v", err, wantErr)
+		}
+		return
+	}
+	got, err := a.GenerateTable()
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GenerateTable() error = %v, wantErr %v", err, wantErr)
+	}
+	if err != nil {
+		return
+	}
+	if len(got) != wantLen {
+		t.Fatalf("length mismatch of rows generate, want=%v, got=%v", wantLen, len(got))
+	}
+	rows := make(map[int]Row)
+	for _, row := range got {
+		rows[int(row.Period)] = row
+	}
+	for period, want := range wantRows {
+		if err := verifyRow(t, rows[period], want); err != nil {
+			t.Fatal(err)
+		}
+		if !rows[period].Prepayment.Equal(want.Prepayment) {
+			t.Fatalf("prepayment mismatch in period %d, want=%v, got=%v", period, want.Prepayment, rows[period].Prepayment)
+		}
+		if want.Type != 0 && rows[period].Type != want.Type {
+			t.Fatalf("row type mismatch in period %d, want=%v, got=%v", period, want.Type, rows[period].Type)
+		}
+	}
+	if err := principalCheck(t, got, config.AmountBorrowed); err != nil {
+		t.Fatal(err)
+	}
+	if err := balanceCheck(t, got, config.AmountBorrowed); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func principalCheck(t *testing.T, rows []Row, actualPrincipal decimal.Decimal) error {
 	expectedPrincipal := decimal.Zero
 	dPrecision := decimal.NewFromFloat(precision)
@@ -104,6 +1410,31 @@ func principalCheck(t *testing.T, rows []Row, actualPrincipal decimal.Decimal) e
 	return nil
 }
 
+func balanceCheck(t *testing.T, rows []Row, actualPrincipal decimal.Decimal) error {
+	dPrecision := decimal.NewFromFloat(precision)
+	balance := actualPrincipal
+	cumulativeInterest := decimal.Zero
+	cumulativePrincipal := decimal.Zero
+	for _, row := range rows {
+		cumulativeInterest = cumulativeInterest.Add(row.Interest)
+		cumulativePrincipal = cumulativePrincipal.Add(row.Principal)
+		if !row.OpeningBalance.Equal(balance) {
+			return fmt.Errorf("opening balance mismatch in period %d. expected:%v, got:%v", row.Period, balance, row.OpeningBalance)
+		}
+		balance = balance.Add(row.Principal)
+		if !row.ClosingBalance.Equal(balance) {
+			return fmt.Errorf("closing balance mismatch in period %d. expected:%v, got:%v", row.Period, balance, row.ClosingBalance)
+		}
+		if !row.CumulativeInterest.Equal(cumulativeInterest) || !row.CumulativePrincipal.Equal(cumulativePrincipal) {
+			return fmt.Errorf("cumulative values mismatch in period %d. expected:(%v,%v), got:(%v,%v)", row.Period, cumulativeInterest, cumulativePrincipal, row.CumulativeInterest, row.CumulativePrincipal)
+		}
+	}
+	if err := isAlmostEqual(rows[len(rows)-1].ClosingBalance, decimal.Zero, dPrecision); err != nil {
+		return fmt.Errorf("error:%v, closing balance of final row is not zero", err.Error())
+	}
+	return nil
+}
+
 func verifyRow(t *testing.T, actual Row, expected Row) error {
 	dPrecision := decimal.NewFromFloat(precision)
 	if err := isAlmostEqual(actual.Principal, expected.Principal, dPrecision); err != nil {
@@ -270,12 +1601,25 @@ func TestPlotRows(t *testing.T) {
 		},
 	}
 
+	// PlotRows writes to the current working directory, hence the test runs in a temporary one.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var err error
 			if err = PlotRows(tt.args.rows, tt.args.fileName); (err != nil) != tt.wantErr {
 				t.Errorf("PlotRows() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if _, err := os.Stat(filepath.Join(dir, tt.args.fileName+".html")); err != nil && !tt.wantErr {
+				t.Errorf("PlotRows() did not save the plot: %v", err)
+			}
 		})
 	}
 }
@@ -337,6 +1681,21 @@ func TestRenderer(t *testing.T) {
 	}
 }
 
+func TestWriteRows(t *testing.T) {
+	rows := getRowsWithRounding(t)
+	var buf bytes.Buffer
+	if err := WriteRows(&buf, rows[:1]); err != nil {
+		t.Fatalf("WriteRows() error = %v", err)
+	}
+	want := "[\n\t{\n\t\t\"Type\": 0,\n\t\t\"Period\": 1,"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("WriteRows() = %s, want prefix %s", buf.String(), want)
+	}
+	if err := WriteRows(&errorWriter{}, rows); err == nil || err.Error() != "error writer" {
+		t.Errorf("WriteRows() error = %v, want error writer", err)
+	}
+}
+
 type errorWriter struct{}
 
 func (er *errorWriter) Write(p []byte) (n int, err error) {
@@ -0,0 +1,31 @@
+package gofinancial
+
+import "github.com/shopspring/decimal"
+
+// Bullet implements financial methods for facilitating a loan use case, in which only the interest is paid every
+// period and the principal is repaid in full along with the final payment.
+type Bullet struct{}
+
+// GetPrincipal returns principal amount contribution in a given period towards a loan, depending on config.
+// It is zero for every period, as the principal repaid in the final period is settled while generating the table.
+func (b *Bullet) GetPrincipal(config Config, period int64) decimal.Decimal {
+	return decimal.Zero
+}
+
+// GetInterest returns interest amount contribution in a given period towards a loan, depending on config.
+func (b *Bullet) GetInterest(config Config, period int64) decimal.Decimal {
+	minusOne := decimal.NewFromInt(-1)
+	return config.getInterestRateForPeriod(period).Mul(config.AmountBorrowed).Mul(minusOne)
+}
+
+// GetPayment returns the periodic payment to be done for a loan depending on config, i.e. the interest of a period.
+func (b *Bullet) GetPayment(config Config) decimal.Decimal {
+	minusOne := decimal.NewFromInt(-1)
+	return config.getInterestRatePerPeriodInDecimal().Mul(config.AmountBorrowed).Mul(minusOne)
+}
+
+// GetPaymentForPeriod returns the payment to be done in a given period, which varies with the interest accrued in
+// the period if a day count convention is specified.
+func (b *Bullet) GetPaymentForPeriod(config Config, period int64) decimal.Decimal {
+	return b.GetInterest(config, period)
+}
@@ -0,0 +1,135 @@
+package gofinancial
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/razorpay/go-financial/enums/businessday"
+)
+
+// holidayLayout is the layout of the dates in a holiday list.
+const holidayLayout = "2006-01-02"
+
+// maxNonBusinessDays limits how far a date is moved in search of a business day.
+const maxNonBusinessDays = 366
+
+// Calendar tells whether a payment can fall due on a date.
+type Calendar interface {
+	IsBusinessDay(date time.Time) bool
+}
+
+// HolidayCalendar is a Calendar in which every day except the weekends and the holidays is a business day.
+type HolidayCalendar struct {
+	weekends map[time.Weekday]bool
+	holidays map[string]bool
+}
+
+// NewHolidayCalendar returns a calendar with the given weekdays as weekends, e.g. time.Saturday and time.Sunday, and
+// the given dates as holidays. The time of the day of the holidays is ignored.
+func NewHolidayCalendar(weekends []time.Weekday, holidays []time.Time) *HolidayCalendar {
+	c := &HolidayCalendar{weekends: make(map[time.Weekday]bool), holidays: make(map[string]bool)}
+	for _, day := range weekends {
+		c.weekends[day] = true
+	}
+	for _, date := range holidays {
+		c.holidays[date.Format(holidayLayout)] = true
+	}
+	return c
+}
+
+/*
+ReadHolidayCalendar returns a calendar with the given weekdays as weekends and the holidays read from the reader.
+
+Every line of the holiday list starts with a date in the YYYY-MM-DD format, optionally followed by a description of the
+holiday. Blank lines and lines starting with # are ignored, e.g.
+
+	# holidays for 2021
+	2021-01-26 Republic Day
+	2021-08-15 Independence Day
+*/
+func ReadHolidayCalendar(r io.Reader, weekends []time.Weekday) (*HolidayCalendar, error) {
+	var holidays []time.Time
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		date, err := time.Parse(holidayLayout, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCalendar, line, err)
+		}
+		holidays = append(holidays, date)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewHolidayCalendar(weekends, holidays), nil
+}
+
+// LoadHolidayCalendar returns a calendar with the given weekdays as weekends and the holidays listed in the file, in
+// the format accepted by ReadHolidayCalendar.
+func LoadHolidayCalendar(path string, weekends []time.Weekday) (*HolidayCalendar, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadHolidayCalendar(file, weekends)
+}
+
+// IsBusinessDay returns false if the date falls on a weekend or a holiday.
+func (c *HolidayCalendar) IsBusinessDay(date time.Time) bool {
+	return !c.weekends[date.Weekday()] && !c.holidays[date.Format(holidayLayout)]
+}
+
+/*
+AdjustDate moves a date that is not a business day as per the calendar to a business day, as per the business day
+convention. The time of the day is preserved.
+
+Params:
+
+	date		: date to be adjusted
+	calendar	: calendar with the business days
+	convention	: FOLLOWING moves the date to the next business day, MODIFIED_FOLLOWING does the same unless it falls
+			  in the next month, in which case the date is moved to the previous business day, and PRECEDING moves
+			  the date to the previous business day
+
+References:
+
+	ISDA 2006 Definitions, Section 4.12 (Business Day Convention).
+*/
+func AdjustDate(date time.Time, calendar Calendar, convention businessday.Type) (time.Time, error) {
+	if calendar == nil {
+		return time.Time{}, fmt.Errorf("%w: no calendar for the business day convention %v", ErrInvalidCalendar, convention)
+	}
+	switch convention {
+	case businessday.FOLLOWING:
+		return getBusinessDay(date, calendar, 1)
+	case businessday.MODIFIED_FOLLOWING:
+		following, err := getBusinessDay(date, calendar, 1)
+		if err != nil || following.Month() == date.Month() {
+			return following, err
+		}
+		return getBusinessDay(date, calendar, -1)
+	case businessday.PRECEDING:
+		return getBusinessDay(date, calendar, -1)
+	default:
+		return time.Time{}, fmt.Errorf("%w: unknown business day convention %d", ErrInvalidBusinessDay, convention)
+	}
+}
+
+// getBusinessDay returns the first business day on or after the date if step is 1, or on or before the date if step
+// is -1.
+func getBusinessDay(date time.Time, calendar Calendar, step int) (time.Time, error) {
+	for i := 0; i <= maxNonBusinessDays; i++ {
+		if day := date.AddDate(0, 0, step*i); calendar.IsBusinessDay(day) {
+			return day, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: no business day within %d days of %v", ErrInvalidCalendar, maxNonBusinessDays, date)
+}
@@ -0,0 +1,109 @@
+package gofinancial
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/razorpay/go-financial/enums/businessday"
+)
+
+func TestAdjustDate(t *testing.T) {
+	calendar := NewHolidayCalendar([]time.Weekday{time.Saturday, time.Sunday}, []time.Time{getDate(2021, 4, 30)})
+	type args struct {
+		date       time.Time
+		calendar   Calendar
+		convention businessday.Type
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Time
+		wantErr error
+	}{
+		{"business day", args{getDate(2021, 4, 29), calendar, businessday.FOLLOWING}, getDate(2021, 4, 29), nil},
+		{"following, weekend", args{getDate(2021, 1, 16), calendar, businessday.FOLLOWING}, getDate(2021, 1, 18), nil},
+		{"following, holiday before weekend", args{getDate(2021, 4, 30), calendar, businessday.FOLLOWING}, getDate(2021, 5, 3), nil},
+		{"modified following, same month", args{getDate(2021, 1, 16), calendar, businessday.MODIFIED_FOLLOWING}, getDate(2021, 1, 18), nil},
+		{"modified following, next month", args{getDate(2021, 4, 30), calendar, businessday.MODIFIED_FOLLOWING}, getDate(2021, 4, 29), nil},
+		{"preceding, weekend", args{getDate(2021, 1, 17), calendar, businessday.PRECEDING}, getDate(2021, 1, 15), nil},
+		{"preserves time of the day", args{time.Date(2021, 1, 31, 23, 59, 59, 0, time.UTC), calendar, businessday.PRECEDING}, time.Date(2021, 1, 29, 23, 59, 59, 0, time.UTC), nil},
+		{"no business day", args{getDate(2021, 1, 16), NewHolidayCalendar([]time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, nil), businessday.FOLLOWING}, time.Time{}, ErrInvalidCalendar},
+		{"no calendar", args{getDate(2021, 1, 16), nil, businessday.FOLLOWING}, time.Time{}, ErrInvalidCalendar},
+		{"invalid convention", args{getDate(2021, 1, 16), calendar, businessday.Type(0)}, time.Time{}, ErrInvalidBusinessDay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AdjustDate(tt.args.date, tt.args.calendar, tt.args.convention)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdjustDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("AdjustDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadHolidayCalendar(t *testing.T) {
+	tests := []struct {
+		name            string
+		holidays        string
+		wantHolidays    []time.Time
+		wantNonHolidays []time.Time
+		wantErr         error
+	}{
+		{
+			name:            "dates with descriptions and comments",
+			holidays:        "# holidays for 2021\n2021-01-26 Republic Day\n\n2021-08-15\tIndependence Day\n",
+			wantHolidays:    []time.Time{getDate(2021, 1, 26), time.Date(2021, 8, 15, 23, 59, 59, 0, time.UTC)},
+			wantNonHolidays: []time.Time{getDate(2021, 1, 27), getDate(2022, 1, 26)},
+		},
+		{
+			name:     "invalid date",
+			holidays: "2021-01-26\n26/01/2021\n",
+			wantErr:  ErrInvalidCalendar,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadHolidayCalendar(strings.NewReader(tt.holidays), nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadHolidayCalendar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, date := range tt.wantHolidays {
+				if got.IsBusinessDay(date) {
+					t.Errorf("IsBusinessDay(%v) = true, want false", date)
+				}
+			}
+			for _, date := range tt.wantNonHolidays {
+				if !got.IsBusinessDay(date) {
+					t.Errorf("IsBusinessDay(%v) = false, want true", date)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadHolidayCalendar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	if err := ioutil.WriteFile(path, []byte("2021-01-26 Republic Day\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	calendar, err := LoadHolidayCalendar(path, []time.Weekday{time.Sunday})
+	if err != nil {
+		t.Fatalf("LoadHolidayCalendar() error = %v", err)
+	}
+	if calendar.IsBusinessDay(getDate(2021, 1, 26)) || calendar.IsBusinessDay(getDate(2021, 1, 24)) {
+		t.Errorf("holiday or weekend is a business day")
+	}
+	if !calendar.IsBusinessDay(getDate(2021, 1, 23)) {
+		t.Errorf("saturday is not a business day")
+	}
+	if _, err := LoadHolidayCalendar(filepath.Join(t.TempDir(), "missing.txt"), nil); err == nil {
+		t.Errorf("LoadHolidayCalendar() for a missing file did not fail")
+	}
+}
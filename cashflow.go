@@ -0,0 +1,147 @@
+package gofinancial
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+// daysInYear is the actual/365 denominator used to convert the days between two cash flows into a year fraction.
+const daysInYear = 365
+
+// CashFlow represents an amount received(+ve) or paid(-ve) on a given date.
+type CashFlow struct {
+	Date   time.Time
+	Amount decimal.Decimal
+}
+
+// GetCashFlows returns the dated cash flows of a loan, i.e. the amount borrowed on the start date followed by
+// the payments in the given rows, which is the input expected by Xnpv and Xirr.
+func (a Amortization) GetCashFlows(rows []Row) []CashFlow {
+	flows := []CashFlow{{Date: a.Config.StartDate, Amount: a.Config.AmountBorrowed}}
+	for _, row := range rows {
+		date := row.EndDate
+		// broken period interest, if paid separately, is collected upfront.
+		if a.Config.PaymentPeriod == paymentperiod.BEGINNING || row.Type == rowtype.BROKEN_PERIOD {
+			date = row.StartDate
+		}
+		flows = append(flows, CashFlow{Date: date, Amount: row.Payment})
+	}
+	return flows
+}
+
+/*
+Xnpv computes the Net Present Value of a cash flow series that is not necessarily periodic, by solving:
+
+	xnpv = sum(amount[i] / (1+rate)**((date[i] - date[0])/365))
+
+Params:
+
+	rate	: an annual discount rate
+	flows	: the dated cash flows, the first of which is the reference date for discounting
+
+References:
+
+	Microsoft Excel XNPV function(https://support.microsoft.com/en-us/office/xnpv-function-1b42bbf6-370f-4532-a0eb-d67c16b664b7)
+*/
+func Xnpv(rate decimal.Decimal, flows []CashFlow) (decimal.Decimal, error) {
+	one := decimal.NewFromInt(1)
+	if one.Add(rate).LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, fmt.Errorf("%w: rate %v must be greater than -1", ErrOutOfBounds, rate)
+	}
+	result := decimal.Zero
+	for _, flow := range flows {
+		result = result.Add(flow.Amount.Div(getDiscountFactor(rate, getYearFraction(flows[0].Date, flow.Date))))
+	}
+	return result, nil
+}
+
+/*
+Xirr computes the Internal Rate of Return of a cash flow series that is not necessarily periodic, by running
+Newton Rapson to find the annual rate for which Xnpv is zero.
+
+Params:
+
+	flows		: the dated cash flows. There must be at least one positive and one negative amount.
+	maxIter 	: total number of iterations to perform calculation
+	tolerance 	: accept result only if the difference in iteration values is less than the tolerance provided
+	initialGuess 	: an initial point to start approximating from
+
+References:
+
+	Microsoft Excel XIRR function(https://support.microsoft.com/en-us/office/xirr-function-de1242ec-6477-445b-b11b-a303ad9adc9d)
+*/
+func Xirr(flows []CashFlow, maxIter int64, tolerance, initialGuess decimal.Decimal) (decimal.Decimal, error) {
+	amounts := make([]decimal.Decimal, len(flows))
+	for i, flow := range flows {
+		amounts[i] = flow.Amount
+	}
+	if !hasSignChange(amounts) {
+		return decimal.Zero, ErrNoSignChange
+	}
+	var nextIterRate, currentIterRate decimal.Decimal = initialGuess, initialGuess
+
+	for iter := int64(0); iter < maxIter; iter++ {
+		currentIterRate = nextIterRate
+		ratio, err := getXirrRatio(flows, currentIterRate)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		nextIterRate = currentIterRate.Sub(ratio)
+		// skip further loops if |nextIterRate-currentIterRate| < tolerance
+		if nextIterRate.Sub(currentIterRate).Abs().LessThan(tolerance) {
+			break
+		}
+	}
+
+	if nextIterRate.Sub(currentIterRate).Abs().GreaterThanOrEqual(tolerance) {
+		return decimal.Zero, ErrTolerence
+	}
+	return nextIterRate, nil
+}
+
+// getXirrRatio returns xnpv(rate)/xnpv'(rate), the newton step used by Xirr.
+func getXirrRatio(flows []CashFlow, rate decimal.Decimal) (decimal.Decimal, error) {
+	one := decimal.NewFromInt(1)
+	if one.Add(rate).LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, ErrTolerence
+	}
+	xnpv := decimal.Zero
+	derivative := decimal.Zero
+	for _, flow := range flows {
+		yearFraction := getYearFraction(flows[0].Date, flow.Date)
+		discounted := flow.Amount.Div(getDiscountFactor(rate, yearFraction))
+		xnpv = xnpv.Add(discounted)
+		// derivative += -t * amount / (1+rate)**(t+1)
+		derivative = derivative.Sub(decimal.NewFromFloat(yearFraction).Mul(discounted).Div(one.Add(rate)))
+	}
+	if derivative.IsZero() {
+		return decimal.Zero, ErrTolerence
+	}
+	return xnpv.Div(derivative), nil
+}
+
+// getDiscountFactor returns (1+rate)**yearFraction.
+func getDiscountFactor(rate decimal.Decimal, yearFraction float64) decimal.Decimal {
+	floatRate, _ := rate.Float64()
+	return decimal.NewFromFloat(math.Pow(1+floatRate, yearFraction))
+}
+
+// getYearFraction returns the actual/365 year fraction between two dates.
+func getYearFraction(from time.Time, to time.Time) float64 {
+	return float64(getDaysBetween(from, to)) / daysInYear
+}
+
+// getDaysBetween returns the number of calendar days from one date to another, ignoring the time of the day.
+func getDaysBetween(from time.Time, to time.Time) int {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	start := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	end := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
@@ -0,0 +1,144 @@
+package gofinancial
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+)
+
+func getCashFlows() []CashFlow {
+	return []CashFlow{
+		{Date: getDate(2008, 1, 1), Amount: decimal.NewFromInt(-10000)},
+		{Date: getDate(2008, 3, 1), Amount: decimal.NewFromInt(2750)},
+		{Date: getDate(2008, 10, 30), Amount: decimal.NewFromInt(4250)},
+		{Date: getDate(2009, 2, 15), Amount: decimal.NewFromInt(3250)},
+		{Date: getDate(2009, 4, 1), Amount: decimal.NewFromInt(2750)},
+	}
+}
+
+func Test_Xnpv(t *testing.T) {
+	type args struct {
+		rate  decimal.Decimal
+		flows []CashFlow
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   decimal.Decimal
+		anyErr error
+	}{
+		{
+			name:   "success",
+			args:   args{rate: decimal.NewFromFloat(0.09), flows: getCashFlows()},
+			want:   decimal.NewFromFloat(2086.647602031535),
+			anyErr: nil,
+		},
+		{
+			name:   "zero rate",
+			args:   args{rate: decimal.Zero, flows: getCashFlows()},
+			want:   decimal.NewFromInt(3000),
+			anyErr: nil,
+		},
+		{
+			name:   "failure, rate less than -1",
+			args:   args{rate: decimal.NewFromFloat(-1.5), flows: getCashFlows()},
+			want:   decimal.Zero,
+			anyErr: ErrOutOfBounds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Xnpv(tt.args.rate, tt.args.flows)
+			if !errors.Is(err, tt.anyErr) || isAlmostEqual(got, tt.want, decimal.NewFromFloat(precision)) != nil {
+				t.Errorf("Xnpv returned (%v,%v), wanted (%v,%v)", got, err, tt.want, tt.anyErr)
+			}
+		})
+	}
+}
+
+func Test_Xirr(t *testing.T) {
+	type args struct {
+		flows        []CashFlow
+		maxIter      int64
+		tolerance    decimal.Decimal
+		initialGuess decimal.Decimal
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   decimal.Decimal
+		anyErr error
+	}{
+		{
+			name: "success", args: args{
+				flows:        getCashFlows(),
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.NewFromFloat(0.37336253351883136),
+			anyErr: nil,
+		}, {
+			name: "failure, no sign change", args: args{
+				flows:        getCashFlows()[1:],
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.Zero,
+			anyErr: ErrNoSignChange,
+		}, {
+			name: "failure, not converged", args: args{
+				flows:        getCashFlows(),
+				maxIter:      2,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.Zero,
+			anyErr: ErrTolerence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Xirr(tt.args.flows, tt.args.maxIter, tt.args.tolerance, tt.args.initialGuess); err != tt.anyErr || isAlmostEqual(got, tt.want, decimal.NewFromFloat(precision)) != nil {
+				t.Errorf("Xirr returned (%v,%v), wanted (%v,%v)", got, err, tt.want, tt.anyErr)
+			}
+		})
+	}
+}
+
+func TestAmortization_GetCashFlows(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	a, err := NewAmortization(config)
+	if err != nil {
+		t.Fatalf("NewAmortization() call failed. error = %v", err)
+	}
+	rows, err := a.GenerateTable()
+	if err != nil {
+		t.Fatalf("GenerateTable() call failed. error = %v", err)
+	}
+	flows := a.GetCashFlows(rows)
+	if len(flows) != len(rows)+1 {
+		t.Fatalf("length mismatch of cash flows, want=%v, got=%v", len(rows)+1, len(flows))
+	}
+	if !flows[0].Date.Equal(config.StartDate) || !flows[0].Amount.Equal(config.AmountBorrowed) {
+		t.Fatalf("first cash flow should be the amount borrowed on start date, got %v", flows[0])
+	}
+	for idx, row := range rows {
+		if !flows[idx+1].Date.Equal(row.EndDate) || !flows[idx+1].Amount.Equal(row.Payment) {
+			t.Fatalf("cash flow %v does not match row %v", flows[idx+1], row)
+		}
+	}
+	// 24% p.a. compounded monthly is an effective annual rate of ~26.82%.
+	got, err := Xirr(flows, 100, decimal.NewFromFloat(1e-7), decimal.NewFromFloat(0.1))
+	if err != nil {
+		t.Fatalf("Xirr() call failed. error = %v", err)
+	}
+	if err := isAlmostEqual(got, decimal.NewFromFloat(0.2682), decimal.NewFromFloat(0.005)); err != nil {
+		t.Errorf("error:%v, unexpected yield for the loan", err)
+	}
+}
@@ -0,0 +1,156 @@
+package gofinancial
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/go-echarts/go-echarts/v2/render"
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/charttype"
+)
+
+// default dimensions and zoom of a chart.
+const (
+	defaultChartWidth  = "1200px"
+	defaultChartHeight = "600px"
+	defaultChartZoom   = 50
+)
+
+// ChartOptions is used to store the type and appearance of a chart of a schedule.
+type ChartOptions struct {
+	Type   charttype.Type // Chart type enum with STACKED_BAR(default), BALANCE_LINE, CUMULATIVE_AREA or SPLIT_PIE value
+	Title  string         // Title of the chart, depending on the chart type by default
+	Width  string         // Width of the chart in CSS units, 1200px by default
+	Height string         // Height of the chart in CSS units, 600px by default
+	Zoom   float32        // Percentage of the periods shown at first by the bar, line and area charts, 50 by default
+}
+
+/*
+WriteChart renders an interactive chart of the rows as HTML into the writer, using the go-echarts package. The chart
+type is one of,
+
+	STACKED_BAR	: principal and interest of every period, stacked, along with the payment
+	BALANCE_LINE	: principal outstanding at the end of every period
+	CUMULATIVE_AREA	: principal and interest paid till every period, stacked
+	SPLIT_PIE	: split of the total payment into principal and interest
+*/
+func WriteChart(w io.Writer, rows []Row, options ChartOptions) error {
+	var chart render.Renderer
+	switch options.Type {
+	case 0, charttype.STACKED_BAR:
+		chart = getStackedBarChart(rows, options)
+	case charttype.BALANCE_LINE:
+		chart = getBalanceLineChart(rows, options)
+	case charttype.CUMULATIVE_AREA:
+		chart = getCumulativeAreaChart(rows, options)
+	case charttype.SPLIT_PIE:
+		chart = getSplitPieChart(rows, options)
+	default:
+		return fmt.Errorf("%w: unknown chart type %d", ErrInvalidChartType, options.Type)
+	}
+	return chart.Render(w)
+}
+
+// SaveChart renders an interactive chart of the rows, as per WriteChart, into the file at the path.
+func SaveChart(rows []Row, filePath string, options ChartOptions) (err error) {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// setting named err
+		ferr := f.Close()
+		if err == nil {
+			err = ferr
+		}
+	}()
+	return WriteChart(f, rows, options)
+}
+
+// getBalanceLineChart returns a line chart of the closing balance of every row.
+func getBalanceLineChart(rows []Row, options ChartOptions) *charts.Line {
+	line := charts.NewLine()
+	line.SetGlobalOptions(options.getGlobalOptions("Outstanding balance", true)...)
+	var xAxis []string
+	var balanceArr []opts.LineData
+	for _, row := range rows {
+		xAxis = append(xAxis, row.EndDate.Format("2006-01-02"))
+		balanceArr = append(balanceArr, opts.LineData{Value: row.ClosingBalance.String()})
+	}
+	line.SetXAxis(xAxis).AddSeries("Outstanding balance", balanceArr)
+	return line
+}
+
+// getCumulativeAreaChart returns a stacked area chart of the cumulative principal and interest of every row.
+func getCumulativeAreaChart(rows []Row, options ChartOptions) *charts.Line {
+	line := charts.NewLine()
+	line.SetGlobalOptions(options.getGlobalOptions("Cumulative principal and interest", true)...)
+	var xAxis []string
+	var interestArr []opts.LineData
+	var principalArr []opts.LineData
+	for _, row := range rows {
+		xAxis = append(xAxis, row.EndDate.Format("2006-01-02"))
+		// negating coz cumulative principal and interest are -ve.
+		interestArr = append(interestArr, opts.LineData{Value: row.CumulativeInterest.Neg().String()})
+		principalArr = append(principalArr, opts.LineData{Value: row.CumulativePrincipal.Neg().String()})
+	}
+	line.SetXAxis(xAxis).
+		AddSeries("Principal", principalArr).
+		AddSeries("Interest", interestArr).
+		SetSeriesOptions(
+			charts.WithLineChartOpts(opts.LineChart{Stack: "stackA"}),
+			charts.WithAreaStyleOpts(opts.AreaStyle{Opacity: 0.5}),
+		)
+	return line
+}
+
+// getSplitPieChart returns a pie chart of the total principal and interest of the rows.
+func getSplitPieChart(rows []Row, options ChartOptions) *charts.Pie {
+	pie := charts.NewPie()
+	pie.SetGlobalOptions(options.getGlobalOptions("Principal and interest", false)...)
+	principal, interest := decimal.Zero, decimal.Zero
+	for _, row := range rows {
+		principal = principal.Add(row.Principal)
+		interest = interest.Add(row.Interest)
+	}
+	pie.AddSeries("Payment", []opts.PieData{
+		{Name: "Principal", Value: principal.Neg().String()},
+		{Name: "Interest", Value: interest.Neg().String()},
+	})
+	return pie
+}
+
+// getGlobalOptions returns the global options of a chart, with the default title of the chart type and, if zoom is
+// enabled, a zoom over the periods.
+func (o *ChartOptions) getGlobalOptions(title string, zoom bool) []charts.GlobalOpts {
+	if o.Title != "" {
+		title = o.Title
+	}
+	width, height, end := o.Width, o.Height, o.Zoom
+	if width == "" {
+		width = defaultChartWidth
+	}
+	if height == "" {
+		height = defaultChartHeight
+	}
+	if end == 0 {
+		end = defaultChartZoom
+	}
+	options := []charts.GlobalOpts{
+		charts.WithTitleOpts(opts.Title{Title: title}),
+		charts.WithInitializationOpts(opts.Initialization{Width: width, Height: height}),
+		charts.WithToolboxOpts(opts.Toolbox{Show: true}),
+		charts.WithLegendOpts(opts.Legend{Show: true}),
+	}
+	if zoom {
+		options = append(options,
+			charts.WithDataZoomOpts(opts.DataZoom{Type: "inside", Start: 0, End: end}),
+			charts.WithDataZoomOpts(opts.DataZoom{Type: "slider", Start: 0, End: end}),
+		)
+	}
+	return options
+}
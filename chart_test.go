@@ -0,0 +1,79 @@
+package gofinancial
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/razorpay/go-financial/enums/charttype"
+)
+
+func TestWriteChart(t *testing.T) {
+	rows := getRowsWithRounding(t)
+	tests := []struct {
+		name         string
+		options      ChartOptions
+		wantContains []string
+		wantErr      error
+	}{
+		{
+			name:         "stacked bar by default",
+			options:      ChartOptions{},
+			wantContains: []string{`"text":"Loan repayment schedule"`, `width:1200px`, `"type":"bar"`, `"stack":"stackA"`, `"end":50`},
+		},
+		{
+			name:         "balance line with title, dimensions and zoom",
+			options:      ChartOptions{Type: charttype.BALANCE_LINE, Title: "Balance", Width: "800px", Height: "400px", Zoom: 100},
+			wantContains: []string{`"text":"Balance"`, `width:800px`, `height:400px`, `"type":"line"`, `"name":"Outstanding balance"`, `"end":100`},
+		},
+		{
+			name:         "cumulative area",
+			options:      ChartOptions{Type: charttype.CUMULATIVE_AREA},
+			wantContains: []string{`"text":"Cumulative principal and interest"`, `"type":"line"`, `"areaStyle":{"opacity":0.5}`, `"stack":"stackA"`},
+		},
+		{
+			name:         "split pie",
+			options:      ChartOptions{Type: charttype.SPLIT_PIE},
+			wantContains: []string{`"text":"Principal and interest"`, `"type":"pie"`, `{"name":"Principal","value":"1000000"}`},
+		},
+		{
+			name:    "unknown chart type",
+			options: ChartOptions{Type: charttype.Type(9)},
+			wantErr: ErrInvalidChartType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteChart(&buf, rows, tt.options)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WriteChart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("WriteChart() output does not contain %s", want)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveChart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "balance.html")
+	if err := SaveChart(getRowsWithRounding(t), path, ChartOptions{Type: charttype.BALANCE_LINE}); err != nil {
+		t.Fatalf("SaveChart() error = %v", err)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading the chart: %v", err)
+	}
+	if !strings.Contains(string(content), `"text":"Outstanding balance"`) {
+		t.Errorf("SaveChart() did not save the chart")
+	}
+	if err := SaveChart(nil, filepath.Join(t.TempDir(), "missing", "balance.html"), ChartOptions{}); err == nil {
+		t.Errorf("SaveChart() error = nil for a missing directory")
+	}
+}
@@ -0,0 +1,200 @@
+package gofinancial
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/components"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+// ScheduleSummary is used to store the totals of a schedule, as +ve amounts, to compare it with other schedules.
+type ScheduleSummary struct {
+	Payment        decimal.Decimal // Payment of the first regular installment, i.e. the EMI
+	TotalInterest  decimal.Decimal // Interest paid over the schedule
+	TotalPrincipal decimal.Decimal // Principal repaid over the schedule
+	TotalPayment   decimal.Decimal // Payments made over the schedule, i.e. the principal and interest
+	EffectiveRate  decimal.Decimal // Effective annual rate of the cash flows, as computed by Xirr
+}
+
+// comparisonTemplate is the summary table of a comparison, shown above its charts.
+var comparisonTemplate = template.Must(template.New("comparison").Parse(`
+<table style="margin: 20px auto; border-collapse: collapse; text-align: right;" border="1" cellpadding="8">
+    <tr><th>Scenario</th><th>EMI</th><th>Total interest</th><th>Total payment</th><th>Effective rate</th></tr>
+    {{- range . }}
+    <tr><td>{{ .Name }}</td><td>{{ .Payment }}</td><td>{{ .TotalInterest }}</td><td>{{ .TotalPayment }}</td><td>{{ .EffectiveRate }}%</td></tr>
+    {{- end }}
+</table>
+`))
+
+// comparisonRow is a row of the summary table of a comparison.
+type comparisonRow struct {
+	Name          string
+	Payment       string
+	TotalInterest string
+	TotalPayment  string
+	EffectiveRate string
+}
+
+/*
+GetScheduleSummary returns the EMI, total interest, total payment and effective annual rate of the rows. The effective
+rate is the Xirr of the opening balance of the first row on its start date followed by the payments, which are taken on
+the end dates of their periods as with the ENDING payment period, save the upfront broken period interest.
+*/
+func GetScheduleSummary(rows []Row) (ScheduleSummary, error) {
+	summary := ScheduleSummary{Payment: getFirstRegularPayment(rows).Neg()}
+	var flows []CashFlow
+	if len(rows) > 0 {
+		flows = append(flows, CashFlow{Date: rows[0].StartDate, Amount: rows[0].OpeningBalance})
+	}
+	for _, row := range rows {
+		summary.TotalInterest = summary.TotalInterest.Sub(row.Interest)
+		summary.TotalPrincipal = summary.TotalPrincipal.Sub(row.Principal)
+		summary.TotalPayment = summary.TotalPayment.Sub(row.Payment)
+		date := row.EndDate
+		if row.Type == rowtype.BROKEN_PERIOD {
+			date = row.StartDate
+		}
+		flows = append(flows, CashFlow{Date: date, Amount: row.Payment})
+	}
+	rate, err := Xirr(flows, 100, decimal.NewFromFloat(1e-7), decimal.NewFromFloat(0.1))
+	if err != nil {
+		return ScheduleSummary{}, fmt.Errorf("%w: effective rate of the schedule", err)
+	}
+	summary.EffectiveRate = rate
+	return summary, nil
+}
+
+/*
+CompareSchedules renders a comparison of loan scenarios, e.g. different tenures or interest types of a loan, as an
+HTML page into the writer, using the go-echarts package. The scenarios are keyed by their names, in the order of which
+the page has,
+
+	a summary table	: EMI, total interest, total payment and effective annual rate of every scenario, as per GetScheduleSummary
+	a line chart	: outstanding balance of every scenario, overlaid by period
+	a bar chart	: total principal and interest of every scenario, stacked
+	bar charts	: principal and interest of every period of a scenario, stacked, as plotted by PlotRows
+
+The options set the title of the line chart and the dimensions and zoom of the charts.
+*/
+func CompareSchedules(w io.Writer, scenarios map[string][]Row, options ChartOptions) error {
+	names := make([]string, 0, len(scenarios))
+	for name := range scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var table []comparisonRow
+	summaries := make(map[string]ScheduleSummary, len(scenarios))
+	for _, name := range names {
+		summary, err := GetScheduleSummary(scenarios[name])
+		if err != nil {
+			return fmt.Errorf("scenario %s: %w", name, err)
+		}
+		summaries[name] = summary
+		table = append(table, comparisonRow{
+			Name:          name,
+			Payment:       summary.Payment.String(),
+			TotalInterest: summary.TotalInterest.String(),
+			TotalPayment:  summary.TotalPayment.String(),
+			EffectiveRate: summary.EffectiveRate.Mul(decimal.NewFromInt(100)).StringFixed(2),
+		})
+	}
+
+	page := components.NewPage()
+	page.PageTitle = "Comparison of loan scenarios"
+	balanceChart, err := getBalanceComparisonChart(names, scenarios, options)
+	if err != nil {
+		return err
+	}
+	page.AddCharts(balanceChart, getTotalComparisonChart(names, summaries, options))
+	for _, name := range names {
+		scenarioOptions := options
+		scenarioOptions.Title = name
+		page.AddCharts(getStackedBarChart(scenarios[name], scenarioOptions))
+	}
+	var buf bytes.Buffer
+	if err := page.Render(&buf); err != nil {
+		return err
+	}
+	var tableBuf bytes.Buffer
+	if err := comparisonTemplate.Execute(&tableBuf, table); err != nil {
+		return err
+	}
+	// go-echarts has no table component, hence the table is put at the top of the body of the page.
+	_, err = io.WriteString(w, strings.Replace(buf.String(), "<body>", "<body>"+tableBuf.String(), 1))
+	return err
+}
+
+// getBalanceComparisonChart returns a line chart of the balance of every scenario by period, starting at period 0, or an
+// error if the period of a row is negative, e.g. of rows read by ReadCSV.
+func getBalanceComparisonChart(names []string, scenarios map[string][]Row, options ChartOptions) (*charts.Line, error) {
+	line := charts.NewLine()
+	line.SetGlobalOptions(options.getGlobalOptions("Outstanding balance", true)...)
+	var last int64
+	for _, name := range names {
+		for _, row := range scenarios[name] {
+			if row.Period < 0 {
+				return nil, fmt.Errorf("scenario %s: %w: period %d must not be negative", name, ErrOutOfBounds, row.Period)
+			}
+			if row.Period > last {
+				last = row.Period
+			}
+		}
+	}
+	var xAxis []string
+	for period := int64(0); period <= last; period++ {
+		xAxis = append(xAxis, strconv.FormatInt(period, 10))
+	}
+	line.SetXAxis(xAxis)
+	for _, name := range names {
+		balanceArr := make([]opts.LineData, len(xAxis))
+		for i := range balanceArr {
+			// a scenario with fewer periods has no balance after its last period.
+			balanceArr[i] = opts.LineData{Value: "-"}
+		}
+		rows := scenarios[name]
+		if len(rows) > 0 {
+			// the balance is the amount borrowed at period 0, unless there is a broken period.
+			balanceArr[0] = opts.LineData{Value: rows[0].OpeningBalance.String()}
+		}
+		for _, row := range rows {
+			balanceArr[row.Period] = opts.LineData{Value: row.ClosingBalance.String()}
+		}
+		line.AddSeries(name, balanceArr)
+	}
+	return line, nil
+}
+
+// getTotalComparisonChart returns a stacked bar chart of the total principal and interest of every scenario.
+func getTotalComparisonChart(names []string, summaries map[string]ScheduleSummary, options ChartOptions) *charts.Bar {
+	bar := charts.NewBar()
+	// the title of the options is that of the balance chart.
+	totalOptions := options
+	totalOptions.Title = ""
+	bar.SetGlobalOptions(totalOptions.getGlobalOptions("Total principal and interest", false)...)
+	var principalArr []opts.BarData
+	var interestArr []opts.BarData
+	for _, name := range names {
+		summary := summaries[name]
+		principalArr = append(principalArr, opts.BarData{Value: summary.TotalPrincipal.String()})
+		interestArr = append(interestArr, opts.BarData{Value: summary.TotalInterest.String()})
+	}
+	bar.SetXAxis(names).
+		AddSeries("Principal", principalArr).
+		AddSeries("Interest", interestArr).SetSeriesOptions(
+		charts.WithBarChartOpts(opts.BarChart{
+			Type:  "bar",
+			Stack: "stackA",
+		}))
+	return bar
+}
@@ -0,0 +1,121 @@
+package gofinancial
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+)
+
+func TestGetScheduleSummary(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []Row
+		want    ScheduleSummary
+		wantErr error
+	}{
+		{
+			name: "24 months reducing",
+			rows: getScenarioRows(t, interesttype.REDUCING, 24),
+			want: ScheduleSummary{
+				Payment:        decimal.NewFromInt(52871),
+				TotalInterest:  decimal.NewFromInt(268904),
+				TotalPrincipal: decimal.NewFromInt(1000000),
+				TotalPayment:   decimal.NewFromInt(1268904),
+				EffectiveRate:  decimal.RequireFromString("0.2687"),
+			},
+		},
+		{
+			name: "12 months flat",
+			rows: getScenarioRows(t, interesttype.FLAT, 12),
+			want: ScheduleSummary{
+				Payment:        decimal.NewFromInt(103333),
+				TotalInterest:  decimal.NewFromInt(240000),
+				TotalPrincipal: decimal.NewFromInt(1000000),
+				TotalPayment:   decimal.NewFromInt(1240000),
+				EffectiveRate:  decimal.RequireFromString("0.5086"),
+			},
+		},
+		{
+			name:    "no rows",
+			rows:    nil,
+			wantErr: ErrNoSignChange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetScheduleSummary(tt.rows)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetScheduleSummary() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got.EffectiveRate = got.EffectiveRate.Round(4)
+			if !got.Payment.Equal(tt.want.Payment) || !got.TotalInterest.Equal(tt.want.TotalInterest) ||
+				!got.TotalPrincipal.Equal(tt.want.TotalPrincipal) || !got.TotalPayment.Equal(tt.want.TotalPayment) ||
+				!got.EffectiveRate.Equal(tt.want.EffectiveRate) {
+				t.Errorf("GetScheduleSummary() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSchedules(t *testing.T) {
+	scenarios := map[string][]Row{
+		"24 months reducing": getScenarioRows(t, interesttype.REDUCING, 24),
+		"12 months reducing": getScenarioRows(t, interesttype.REDUCING, 12),
+		"12 months flat":     getScenarioRows(t, interesttype.FLAT, 12),
+	}
+	var buf bytes.Buffer
+	if err := CompareSchedules(&buf, scenarios, ChartOptions{Title: "Tenures"}); err != nil {
+		t.Fatalf("CompareSchedules() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<title>Comparison of loan scenarios</title>",
+		"<tr><td>24 months reducing</td><td>52871</td><td>268904</td><td>1268904</td><td>26.87%</td></tr>",
+		`"text":"Tenures"`,
+		`"text":"Total principal and interest"`,
+		`"text":"12 months flat"`,
+		`"name":"24 months reducing","type":"line"`,
+		`"data":[{"value":"1000000"}`,
+		`{"value":"0","XAxisIndex":0,"YAxisIndex":0},{"value":"-"`,
+		`"data":["12 months flat","12 months reducing","24 months reducing"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("CompareSchedules() output does not contain %s", want)
+		}
+	}
+	if strings.Index(got, "12 months flat</td>") > strings.Index(got, "12 months reducing</td>") {
+		t.Errorf("CompareSchedules() did not sort the scenarios by name")
+	}
+
+	negative := getScenarioRows(t, interesttype.REDUCING, 12)
+	negative[0].Period = -1
+	if err := CompareSchedules(&buf, map[string][]Row{"negative period": negative}, ChartOptions{}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("CompareSchedules() error = %v, want %v", err, ErrOutOfBounds)
+	}
+
+	scenarios["empty"] = nil
+	if err := CompareSchedules(&buf, scenarios, ChartOptions{}); !errors.Is(err, ErrNoSignChange) {
+		t.Errorf("CompareSchedules() error = %v, want %v", err, ErrNoSignChange)
+	}
+}
+
+func getScenarioRows(t *testing.T, interestType interesttype.Type, months int) []Row {
+	config := getConfigDto(frequency.MONTHLY, true, interestType, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	config.EndDate = config.StartDate.AddDate(0, months, 0).Add(-time.Second)
+	a, err := NewAmortization(config)
+	if err != nil {
+		t.Fatalf("NewAmortization() call failed. error = %v", err)
+	}
+	rows, err := a.GenerateTable()
+	if err != nil {
+		t.Fatalf("GenerateTable() error = %v", err)
+	}
+	return rows
+}
@@ -1,45 +1,125 @@
 package gofinancial
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/shopspring/decimal"
 
+	"github.com/razorpay/go-financial/enums/brokenperiod"
+	"github.com/razorpay/go-financial/enums/businessday"
+	"github.com/razorpay/go-financial/enums/daycount"
+	"github.com/razorpay/go-financial/enums/dueday"
 	"github.com/razorpay/go-financial/enums/paymentperiod"
+	"github.com/razorpay/go-financial/enums/prepaymentstrategy"
+	"github.com/razorpay/go-financial/enums/resetstrategy"
+	"github.com/razorpay/go-financial/enums/residualallocation"
+	"github.com/razorpay/go-financial/enums/roundingmode"
 
 	"github.com/razorpay/go-financial/enums/interesttype"
 
 	"github.com/razorpay/go-financial/enums/frequency"
 )
 
+// semiMonthlyDay is the day of the month on which the second semi-monthly period of every month starts.
+const semiMonthlyDay = 15
+
 // Config is used to store details used in generation of amortization table.
 type Config struct {
-	StartDate              time.Time          // Starting day of the amortization schedule(inclusive)
-	EndDate                time.Time          // Ending day of the amortization schedule(inclusive)
-	Frequency              frequency.Type     // Frequency enum with DAILY, WEEKLY, MONTHLY or ANNUALLY
-	AmountBorrowed         decimal.Decimal    // Amount Borrowed
-	InterestType           interesttype.Type  // InterestType enum with FLAT or REDUCING value.
-	Interest               decimal.Decimal    // Interest in basis points
-	PaymentPeriod          paymentperiod.Type // Payment period enum to know whether payment made at the BEGINNING or ENDING of a period
-	EnableRounding         bool               // If enabled, the final values in amortization schedule are rounded
-	RoundingPlaces         int32              // If specified, the final values in amortization schedule are rounded to these many places
-	RoundingErrorTolerance decimal.Decimal    // Any difference in [payment-(principal+interest)] will be adjusted in interest component, upto the RoundingErrorTolerance value specified
-	periods                int64              // derived
-	startDates             []time.Time        // derived
-	endDates               []time.Time        // derived
-}
-
-func (c *Config) setPeriodsAndDates() error {
+	StartDate              time.Time               // Starting day of the amortization schedule(inclusive)
+	EndDate                time.Time               // Ending day of the amortization schedule(inclusive)
+	Frequency              frequency.Type          // Frequency enum with DAILY, WEEKLY, BIWEEKLY, SEMI_MONTHLY(1st and 15th), MONTHLY, QUARTERLY, HALF_YEARLY or ANNUALLY
+	CompoundingFrequency   frequency.Type          // If specified, the interest compounds at this frequency instead of the payment Frequency, not for FLAT or RULE_OF_78
+	AmountBorrowed         decimal.Decimal         // Amount Borrowed
+	InterestType           interesttype.Type       // InterestType enum with FLAT, REDUCING, BULLET, EQUAL_PRINCIPAL or RULE_OF_78 value.
+	Interest               decimal.Decimal         // Interest in basis points
+	PaymentPeriod          paymentperiod.Type      // Payment period enum to know whether payment made at the BEGINNING or ENDING of a period
+	EnableRounding         bool                    // If enabled, the final values in amortization schedule are rounded
+	RoundingPlaces         int32                   // If specified, the final values in amortization schedule are rounded to these many places
+	RoundingMode           roundingmode.Type       // Rounding mode enum with HALF_UP(default), HALF_EVEN, UP, DOWN, CEILING or FLOOR value
+	RoundingIncrement      decimal.Decimal         // If specified, the final values are rounded to a multiple of it, e.g. 10 paise, which must fit in RoundingPlaces
+	RoundingErrorTolerance decimal.Decimal         // Any difference in [payment-(principal+interest)] will be adjusted in interest component, upto the RoundingErrorTolerance value specified
+	ResidualAllocation     residualallocation.Type // Residual allocation enum with ADJUST_LAST_INSTALLMENT(default), ADJUST_FIRST_INSTALLMENT, SPREAD or ADJUST_INTEREST value, for the principal left over by rounding
+	Prepayments            []Prepayment            // Part-payments made towards the principal, after which the outstanding principal is re-amortised
+	PrepaymentStrategy     prepaymentstrategy.Type // Prepayment strategy enum with REDUCE_EMI(default) or REDUCE_TENURE value
+	DayCountConvention     daycount.Type           // If specified, interest for a period accrues as per the actual start and end dates of the period
+	FirstDueDate           time.Time               // If specified, the first regular period ends on this date and the days before it form a broken period
+	BrokenPeriodInterest   brokenperiod.Type       // Broken period interest enum with UPFRONT(default), ADD_TO_FIRST_INSTALLMENT or CAPITALISE value
+	Moratorium             Moratorium              // If specified, no principal is repaid in the first Moratorium.Periods periods
+	RateResets             []RateReset             // Changes in the rate of interest of a floating rate loan, after which the outstanding principal is re-amortised
+	RateResetStrategy      resetstrategy.Type      // Rate reset strategy enum with KEEP_TENURE(default) or KEEP_EMI value
+	BalloonAmount          decimal.Decimal         // If specified, this much principal is left outstanding after the last regular payment and is repaid along with it
+	Step                   Step                    // If specified, the EMI of a REDUCING rate loan steps up or down periodically
+	Calendar               Calendar                // Calendar with the business days, needed for the BusinessDayConvention
+	BusinessDayConvention  businessday.Type        // If specified, due dates are moved to business days with FOLLOWING, MODIFIED_FOLLOWING or PRECEDING value
+	AccrueOnAdjustedDates  bool                    // If enabled, interest accrues up to the adjusted due dates as per the DayCountConvention, which must be specified
+	DueDayAnchor           dueday.Type             // If specified, periods of whole months are due on a FIXED_DAY of the month(clamped to the month end) or the LAST_DAY of the month
+	brokenPeriodEndDate    time.Time               // derived, zero if there is no broken period
+	firstAnchoredDueDate   time.Time               // derived, unadjusted due date of the first regular period if DueDayAnchor is specified
+	dueDay                 int                     // derived, day of the month of the anchored due dates, 0 for the last day of the month
+	periods                int64                   // derived
+	periodOffset           int64                   // derived, number of periods of the schedule before the first period of this config
+	startDates             []time.Time             // derived
+	endDates               []time.Time             // derived
+	yearFractions          []decimal.Decimal       // derived, only if DayCountConvention is specified
+	schedule               *reducingSchedule       // derived, payments and interest of a segment made by getSegment, solved on first use
+}
+
+// setPeriodsAndDates sets the periods and dates of the schedule, either up to the end date or, if the tenure is not
+// zero, for that many regular periods from the start date, after which the end date is set to the last day of the
+// schedule. Any periods and dates derived earlier are replaced.
+func (c *Config) setPeriodsAndDates(tenure int64) error {
+	c.periods, c.startDates, c.endDates, c.yearFractions = 0, nil, nil, nil
+	c.brokenPeriodEndDate, c.firstAnchoredDueDate, c.dueDay = time.Time{}, time.Time{}, 0
+	if c.CompoundingFrequency != 0 && c.CompoundingFrequency.Value() == 0 {
+		return fmt.Errorf("%w: unknown compounding frequency %d", ErrInvalidFrequency, c.CompoundingFrequency)
+	}
 	sy, sm, sd := c.StartDate.Date()
 	startDate := time.Date(sy, sm, sd, 0, 0, 0, 0, c.StartDate.Location())
 
 	ey, em, ed := c.EndDate.Date()
 	endDate := time.Date(ey, em, ed, 0, 0, 0, 0, c.EndDate.Location())
 
-	period, err := GetPeriodDifference(startDate, endDate, c.Frequency)
-	if err != nil {
+	if err := c.validateBusinessDayConvention(); err != nil {
 		return err
 	}
+	if c.DueDayAnchor != 0 {
+		if err := c.setAnchoredDates(startDate, endDate, tenure); err != nil {
+			return err
+		}
+		c.setTenureEndDate(tenure)
+		return c.adjustDatesAndSetYearFractions(0)
+	}
+
+	firstDate := c.StartDate
+	if !c.FirstDueDate.IsZero() {
+		dy, dm, dd := c.FirstDueDate.Date()
+		dueDate := time.Date(dy, dm, dd, 0, 0, 0, 0, c.StartDate.Location())
+		// the first regular period ends on the first due date.
+		regularStartDate, err := getStartDate(dueDate.AddDate(0, 0, 1), c.Frequency, -1)
+		if err != nil {
+			return err
+		}
+		if regularStartDate.Before(startDate) {
+			return fmt.Errorf("%w: first regular period ending on %v starts on %v, before the start date %v", ErrInvalidFirstDueDate, c.FirstDueDate, regularStartDate, startDate)
+		}
+		if regularStartDate.After(startDate) {
+			brokenPeriodEndDate := regularStartDate.AddDate(0, 0, -1)
+			c.brokenPeriodEndDate = time.Date(brokenPeriodEndDate.Year(), brokenPeriodEndDate.Month(), brokenPeriodEndDate.Day(), 23, 59, 59, 0, brokenPeriodEndDate.Location())
+			startDate = regularStartDate
+			firstDate = regularStartDate
+		}
+	}
+
+	period := int(tenure)
+	if tenure == 0 {
+		var err error
+		if period, err = GetPeriodDifference(startDate, endDate, c.Frequency); err != nil {
+			return err
+		}
+	}
 	c.periods = int64(period)
 	for i := 0; i < period; i++ {
 		date, err := getStartDate(startDate, c.Frequency, i)
@@ -47,7 +127,7 @@ func (c *Config) setPeriodsAndDates() error {
 			return err
 		}
 		if i == 0 {
-			c.startDates = append(c.startDates, c.StartDate)
+			c.startDates = append(c.startDates, firstDate)
 		} else {
 			c.startDates = append(c.startDates, date)
 		}
@@ -57,9 +137,175 @@ func (c *Config) setPeriodsAndDates() error {
 			c.endDates = append(c.endDates, endDate)
 		}
 	}
+	c.setTenureEndDate(tenure)
+	return c.adjustDatesAndSetYearFractions(0)
+}
+
+// setTenureEndDate sets the end date to the last day of the unadjusted schedule, if it is built for a tenure.
+func (c *Config) setTenureEndDate(tenure int64) {
+	if tenure == 0 {
+		return
+	}
+	ly, lm, ld := c.endDates[len(c.endDates)-1].Date()
+	c.EndDate = time.Date(ly, lm, ld, 0, 0, 0, 0, c.StartDate.Location())
+}
+
+// adjustDatesAndSetYearFractions moves the due dates of the periods from the given index onwards to business days, and
+// computes the fractions of a year in these periods from either the unadjusted or the adjusted dates.
+func (c *Config) adjustDatesAndSetYearFractions(from int) error {
+	if !c.AccrueOnAdjustedDates {
+		if err := c.setYearFractions(from); err != nil {
+			return err
+		}
+		return c.adjustDates(from)
+	}
+	if err := c.adjustDates(from); err != nil {
+		return err
+	}
+	return c.setYearFractions(from)
+}
+
+// validateBusinessDayConvention returns an error if the due dates cannot be adjusted as per the business day convention.
+func (c *Config) validateBusinessDayConvention() error {
+	if c.BusinessDayConvention == 0 {
+		if c.AccrueOnAdjustedDates {
+			return fmt.Errorf("%w: no business day convention to adjust the dates to accrue interest on", ErrInvalidBusinessDay)
+		}
+		return nil
+	}
+	if c.BusinessDayConvention.String() == "" {
+		return fmt.Errorf("%w: unknown business day convention %d", ErrInvalidBusinessDay, c.BusinessDayConvention)
+	}
+	if c.Calendar == nil {
+		return fmt.Errorf("%w: no calendar for the business day convention %v", ErrInvalidCalendar, c.BusinessDayConvention)
+	}
+	if c.AccrueOnAdjustedDates && c.DayCountConvention == 0 {
+		return fmt.Errorf("%w: interest accrues on the adjusted dates only as per a day count convention", ErrInvalidBusinessDay)
+	}
+	return nil
+}
+
+// adjustDates moves the due date, i.e. the end date, of every period from the given index onwards to a business day
+// as per the business day convention, and the start date of the next period to the day after it.
+func (c *Config) adjustDates(from int) error {
+	if c.BusinessDayConvention == 0 {
+		return nil
+	}
+	for i := from; i < len(c.endDates); i++ {
+		if i > 0 {
+			previous := c.endDates[i-1].AddDate(0, 0, 1)
+			c.startDates[i] = time.Date(previous.Year(), previous.Month(), previous.Day(), 0, 0, 0, 0, previous.Location())
+		}
+		endDate, err := AdjustDate(c.endDates[i], c.Calendar, c.BusinessDayConvention)
+		if err != nil {
+			return err
+		}
+		if endDate.Before(c.startDates[i]) {
+			return fmt.Errorf("%w: due date of period %d moves to %v, before its start date %v", ErrInvalidBusinessDay, i+1, endDate, c.startDates[i])
+		}
+		c.endDates[i] = endDate
+	}
+	return nil
+}
+
+// setYearFractions computes the fraction of a year in every period from the given index onwards as per the day count
+// convention, if specified.
+func (c *Config) setYearFractions(from int) error {
+	if c.DayCountConvention == 0 {
+		return nil
+	}
+	for i := from; i < len(c.startDates); i++ {
+		// end dates are inclusive.
+		fraction, err := YearFraction(c.startDates[i], c.endDates[i].AddDate(0, 0, 1), c.DayCountConvention)
+		if err != nil {
+			return err
+		}
+		c.yearFractions = append(c.yearFractions, fraction)
+	}
 	return nil
 }
 
+// extendPeriods extends the schedule beyond the end date to the given number of periods.
+func (c *Config) extendPeriods(periods int64) error {
+	if periods <= c.periods {
+		return nil
+	}
+	// the slices are copied so that the extended dates are not visible to other configs sharing them.
+	startDates := append(make([]time.Time, 0, periods), c.startDates...)
+	endDates := append(make([]time.Time, 0, periods), c.endDates...)
+	fy, fm, fd := c.startDates[0].Date()
+	firstDate := time.Date(fy, fm, fd, 0, 0, 0, 0, c.startDates[0].Location())
+	for i := c.periods; i < periods; i++ {
+		if c.DueDayAnchor != 0 {
+			previous := endDates[i-1]
+			startDates = append(startDates, time.Date(previous.Year(), previous.Month(), previous.Day()+1, 0, 0, 0, 0, previous.Location()))
+			endDates = append(endDates, c.getAnchoredDueDate(int(i)))
+			continue
+		}
+		date, err := getStartDate(firstDate, c.Frequency, int(i))
+		if err != nil {
+			return err
+		}
+		startDates = append(startDates, date)
+		endDate, err := getEndDates(date, c.Frequency)
+		if err != nil {
+			return err
+		}
+		endDates = append(endDates, endDate)
+	}
+	from := int(c.periods)
+	c.periods = periods
+	c.startDates = startDates
+	c.endDates = endDates
+	if c.yearFractions != nil {
+		c.yearFractions = append(make([]decimal.Decimal, 0, periods), c.yearFractions...)
+	}
+	return c.adjustDatesAndSetYearFractions(from)
+}
+
+/*
+GetTenure returns the number of periods of the given frequency from the start date to the end date, both inclusive.
+If the end date is not the last day of a period, the error describes the nearest end dates of whole periods.
+
+Params:
+
+	from	: starting date of the schedule(inclusive)
+	to	: ending date of the schedule(inclusive)
+	freq	: frequency of the periods
+*/
+func GetTenure(from time.Time, to time.Time, freq frequency.Type) (int64, error) {
+	fy, fm, fd := from.Date()
+	from = time.Date(fy, fm, fd, 0, 0, 0, 0, from.Location())
+	ty, tm, td := to.Date()
+	to = time.Date(ty, tm, td, 0, 0, 0, 0, to.Location())
+	if to.Before(from) {
+		return 0, fmt.Errorf("%w: end date %v is before the start date %v", ErrUnevenEndDate, to.Format(holidayLayout), from.Format(holidayLayout))
+	}
+	periods, err := GetPeriodDifference(from, to, freq)
+	if err == nil {
+		return int64(periods), nil
+	}
+	if !errors.Is(err, ErrUnevenEndDate) {
+		return 0, err
+	}
+	// the schedule of n periods ends on the day before the start of period n+1.
+	var before time.Time
+	for n := 1; ; n++ {
+		next, err := getStartDate(from, freq, n)
+		if err != nil {
+			return 0, err
+		}
+		after := next.AddDate(0, 0, -1)
+		if after.After(to) {
+			if n == 1 {
+				return 0, fmt.Errorf("%w: %v is before the end of the first %v period on %v", ErrUnevenEndDate, to.Format(holidayLayout), freq, after.Format(holidayLayout))
+			}
+			return 0, fmt.Errorf("%w: %v is not the last day of a %v period, %d periods end on %v and %d periods end on %v", ErrUnevenEndDate, to.Format(holidayLayout), freq, n-1, before.Format(holidayLayout), n, after.Format(holidayLayout))
+		}
+		before = after
+	}
+}
+
 func GetPeriodDifference(from time.Time, to time.Time, freq frequency.Type) (int, error) {
 	var periods int
 	switch freq {
@@ -68,15 +314,39 @@ func GetPeriodDifference(from time.Time, to time.Time, freq frequency.Type) (int
 	case frequency.WEEKLY:
 		days := int(to.Sub(from).Hours()/24) + 1
 		if days%7 != 0 {
-			return -1, ErrUnevenEndDate
+			return -1, fmt.Errorf("%w: %d days from %v to %v are not whole weeks", ErrUnevenEndDate, days, from, to)
 		}
 		periods = days / 7
+	case frequency.BIWEEKLY:
+		days := int(to.Sub(from).Hours()/24) + 1
+		if days%14 != 0 {
+			return -1, fmt.Errorf("%w: %d days from %v to %v are not whole fortnights", ErrUnevenEndDate, days, from, to)
+		}
+		periods = days / 14
+	case frequency.SEMI_MONTHLY:
+		halfMonths, err := getHalfMonthsBetweenDates(from, to)
+		if err != nil {
+			return -1, err
+		}
+		periods = *halfMonths
 	case frequency.MONTHLY:
-		months, err := getMonthsBetweenDates(from, to)
+		months, err := getMonthsBetweenDates(from, to, 1)
 		if err != nil {
 			return -1, err
 		}
 		periods = *months
+	case frequency.QUARTERLY:
+		quarters, err := getMonthsBetweenDates(from, to, 3)
+		if err != nil {
+			return -1, err
+		}
+		periods = *quarters
+	case frequency.HALF_YEARLY:
+		halfYears, err := getMonthsBetweenDates(from, to, 6)
+		if err != nil {
+			return -1, err
+		}
+		periods = *halfYears
 	case frequency.ANNUALLY:
 		years, err := getYearsBetweenDates(from, to)
 		if err != nil {
@@ -84,7 +354,7 @@ func GetPeriodDifference(from time.Time, to time.Time, freq frequency.Type) (int
 		}
 		periods = *years
 	default:
-		return -1, ErrInvalidFrequency
+		return -1, fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, freq)
 	}
 	return periods, nil
 }
@@ -96,25 +366,76 @@ func getStartDate(date time.Time, freq frequency.Type, index int) (time.Time, er
 		startDate = date.AddDate(0, 0, index)
 	case frequency.WEEKLY:
 		startDate = date.AddDate(0, 0, 7*index)
+	case frequency.BIWEEKLY:
+		startDate = date.AddDate(0, 0, 14*index)
+	case frequency.SEMI_MONTHLY:
+		return getHalfMonthStartDate(date, index)
 	case frequency.MONTHLY:
 		startDate = date.AddDate(0, index, 0)
+	case frequency.QUARTERLY:
+		startDate = date.AddDate(0, 3*index, 0)
+	case frequency.HALF_YEARLY:
+		startDate = date.AddDate(0, 6*index, 0)
 	case frequency.ANNUALLY:
 		startDate = date.AddDate(index, 0, 0)
 	default:
-		return time.Time{}, ErrInvalidFrequency
+		return time.Time{}, fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, freq)
 	}
 	return startDate, nil
 }
 
-func getMonthsBetweenDates(start time.Time, end time.Time) (*int, error) {
+// getHalfMonthStartDate returns the start date of the semi-monthly period, index periods after the one starting on the
+// given date. Semi-monthly periods start on the 1st and the 15th of every month.
+func getHalfMonthStartDate(date time.Time, index int) (time.Time, error) {
+	var half int
+	switch date.Day() {
+	case 1:
+		half = index
+	case semiMonthlyDay:
+		half = index + 1
+	default:
+		return time.Time{}, fmt.Errorf("%w: semi-monthly periods start on the 1st or the %dth, not on %v", ErrUnevenStartDate, semiMonthlyDay, date)
+	}
+	months := half / 2
+	if half < 0 && half%2 != 0 {
+		// rounding down for the periods before the given date.
+		months--
+	}
+	day := 1
+	if half-2*months == 1 {
+		day = semiMonthlyDay
+	}
+	return time.Date(date.Year(), date.Month()+time.Month(months), day, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location()), nil
+}
+
+func getHalfMonthsBetweenDates(start time.Time, end time.Time) (*int, error) {
+	count := 0
+	next := start
+	for !next.After(end) {
+		count++
+		date, err := getHalfMonthStartDate(start, count)
+		if err != nil {
+			return nil, err
+		}
+		next = date
+	}
+	finalDate := next.AddDate(0, 0, -1)
+	if !finalDate.Equal(end) {
+		return nil, fmt.Errorf("%w: %v is not the last day of a period, the next one is %v", ErrUnevenEndDate, end, finalDate)
+	}
+	return &count, nil
+}
+
+// getMonthsBetweenDates returns the number of periods of the given number of months between the dates.
+func getMonthsBetweenDates(start time.Time, end time.Time, months int) (*int, error) {
 	count := 0
 	for start.Before(end) {
-		start = start.AddDate(0, 1, 0)
+		start = start.AddDate(0, months, 0)
 		count++
 	}
 	finalDate := start.AddDate(0, 0, -1)
 	if !finalDate.Equal(end) {
-		return nil, ErrUnevenEndDate
+		return nil, fmt.Errorf("%w: %v is not the last day of a period, the next one is %v", ErrUnevenEndDate, end, finalDate)
 	}
 	return &count, nil
 }
@@ -127,7 +448,7 @@ func getYearsBetweenDates(start time.Time, end time.Time) (*int, error) {
 	}
 	finalDate := start.AddDate(0, 0, -1)
 	if !finalDate.Equal(end) {
-		return nil, ErrUnevenEndDate
+		return nil, fmt.Errorf("%w: %v is not the last day of a period, the next one is %v", ErrUnevenEndDate, end, finalDate)
 	}
 	return &count, nil
 }
@@ -140,23 +461,116 @@ func getEndDates(date time.Time, freq frequency.Type) (time.Time, error) {
 	case frequency.WEEKLY:
 		date = date.AddDate(0, 0, 6)
 		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
+	case frequency.BIWEEKLY:
+		date = date.AddDate(0, 0, 13)
+		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
+	case frequency.SEMI_MONTHLY:
+		if date.Day() < semiMonthlyDay {
+			nextDate = time.Date(date.Year(), date.Month(), semiMonthlyDay-1, 23, 59, 59, 0, date.Location())
+		} else {
+			// day 0 of the next month is the last day of this month.
+			nextDate = time.Date(date.Year(), date.Month()+1, 0, 23, 59, 59, 0, date.Location())
+		}
 	case frequency.MONTHLY:
 		date = date.AddDate(0, 1, 0).AddDate(0, 0, -1)
 		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
+	case frequency.QUARTERLY:
+		date = date.AddDate(0, 3, 0).AddDate(0, 0, -1)
+		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
+	case frequency.HALF_YEARLY:
+		date = date.AddDate(0, 6, 0).AddDate(0, 0, -1)
+		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
 	case frequency.ANNUALLY:
 		date = date.AddDate(1, 0, 0).AddDate(0, 0, -1)
 		nextDate = time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, date.Location())
 	default:
-		return time.Time{}, ErrInvalidFrequency
+		return time.Time{}, fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, freq)
 	}
 	return nextDate, nil
 }
 
+// getInterestRatePerPeriodInDecimal returns the rate of interest for a period. If the interest compounds at a different
+// frequency than the payments, it is the equivalent periodic rate (1 + r/m)**(m/p) - 1, for an annual rate r compounded
+// m times a year and p payments a year.
 func (c *Config) getInterestRatePerPeriodInDecimal() decimal.Decimal {
-	hundred := decimal.NewFromInt(100)
 	freq := decimal.NewFromInt(int64(c.Frequency.Value()))
+	if c.isCompoundedSeparately() {
+		return c.getInterestRateForYearFraction(decimal.NewFromInt(1).Div(freq))
+	}
+	InterestPerPeriod := c.getInterestRateInDecimal().Div(freq)
+	return InterestPerPeriod
+}
+
+// isCompoundedSeparately returns true if the interest compounds at a different frequency than the payments.
+func (c *Config) isCompoundedSeparately() bool {
+	return c.CompoundingFrequency != 0 && c.CompoundingFrequency != c.Frequency
+}
+
+// getInterestRateForYearFraction returns the rate of interest for a fraction of a year, i.e. the annual rate prorated
+// for the fraction, or the equivalent rate (1 + r/m)**(m*fraction) - 1 if the interest compounds separately m times a
+// year.
+func (c *Config) getInterestRateForYearFraction(fraction decimal.Decimal) decimal.Decimal {
+	if !c.isCompoundedSeparately() {
+		return c.getInterestRateInDecimal().Mul(fraction)
+	}
+	m := float64(c.CompoundingFrequency.Value())
+	floatRate, _ := c.getInterestRateInDecimal().Float64()
+	floatFraction, _ := fraction.Float64()
+	return decimal.NewFromFloat(math.Pow(1+floatRate/m, m*floatFraction) - 1)
+}
+
+// validateBalloonAmount returns an error if the balloon amount is more than the amount borrowed. A negative balloon
+// amount is reported by Validate.
+func (c *Config) validateBalloonAmount() error {
+	if c.BalloonAmount.GreaterThan(c.AmountBorrowed) {
+		return fmt.Errorf("%w: %v for amount borrowed %v", ErrInvalidBalloonAmount, c.BalloonAmount, c.AmountBorrowed)
+	}
+	return nil
+}
+
+// getBalloonAmount returns the principal to be left outstanding after the last regular payment. It is capped to the
+// amount borrowed, which is the outstanding principal while re-amortising after a prepayment.
+func (c *Config) getBalloonAmount() decimal.Decimal {
+	if c.BalloonAmount.GreaterThan(c.AmountBorrowed) {
+		return c.AmountBorrowed
+	}
+	return c.BalloonAmount
+}
+
+// hasBrokenPeriod returns true if the schedule starts with a broken period before the first regular period.
+func (c *Config) hasBrokenPeriod() bool {
+	return !c.brokenPeriodEndDate.IsZero()
+}
+
+// getBrokenPeriodInterest returns the interest accrued on the amount borrowed during the broken period. The days are
+// counted as per the day count convention, or ACT/365 Fixed if it is not specified.
+func (c *Config) getBrokenPeriodInterest() (decimal.Decimal, error) {
+	convention := c.DayCountConvention
+	if convention == 0 {
+		convention = daycount.ACT_365_FIXED
+	}
+	// end date is inclusive.
+	fraction, err := YearFraction(c.StartDate, c.brokenPeriodEndDate.AddDate(0, 0, 1), convention)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	minusOne := decimal.NewFromInt(-1)
+	return c.getInterestRateForYearFraction(fraction).Mul(c.AmountBorrowed).Mul(minusOne), nil
+}
+
+// getInterestRateInDecimal returns the annual rate of interest as a decimal, e.g. 0.12 for 1200 basis points.
+func (c *Config) getInterestRateInDecimal() decimal.Decimal {
+	hundred := decimal.NewFromInt(100)
 	interestInPercent := c.Interest.Div(hundred)
 	InterestInDecimal := interestInPercent.Div(hundred)
-	InterestPerPeriod := InterestInDecimal.Div(freq)
-	return InterestPerPeriod
+	return InterestInDecimal
+}
+
+// getInterestRateForPeriod returns the rate of interest for the given period. If a day count convention is specified,
+// the annual rate is applied for the actual length of the period, otherwise it is the same for every period.
+func (c *Config) getInterestRateForPeriod(period int64) decimal.Decimal {
+	if c.yearFractions == nil {
+		return c.getInterestRatePerPeriodInDecimal()
+	}
+	return c.getInterestRateForYearFraction(c.yearFractions[period-1])
 }
@@ -1,10 +1,16 @@
 package gofinancial
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/businessday"
+	"github.com/razorpay/go-financial/enums/daycount"
+	"github.com/razorpay/go-financial/enums/dueday"
 	Frequency "github.com/razorpay/go-financial/enums/frequency"
 )
 
@@ -196,6 +202,58 @@ func TestConfig_SetPeriodsAndDates(t *testing.T) {
 				{timeParseUtil(t, "2021-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2022-04-30 23:59:59 +0000 UTC")},
 			},
 		},
+		{
+			name: "biweekly", fields: fields{getDate(2020, 1, 1), getDate(2020, 2, 25), Frequency.BIWEEKLY}, wantErr: false,
+			wantPeriods: 4, wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-01-14 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-01-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-01-28 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-01-29 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-11 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-02-12 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-25 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "biweekly uneven end date", fields: fields{getDate(2020, 1, 1), getDate(2020, 2, 24), Frequency.BIWEEKLY}, wantErr: true,
+		},
+		{
+			name: "semi-monthly from the 1st", fields: fields{getDate(2020, 1, 1), getDate(2020, 2, 29), Frequency.SEMI_MONTHLY}, wantErr: false,
+			wantPeriods: 4, wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-01-14 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-01-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-01-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-02-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-14 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-02-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "semi-monthly from the 15th, different year", fields: fields{getDate(2020, 12, 15), getDate(2021, 1, 14), Frequency.SEMI_MONTHLY}, wantErr: false,
+			wantPeriods: 2, wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-12-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-12-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2021-01-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-01-14 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "semi-monthly uneven start date", fields: fields{getDate(2020, 1, 2), getDate(2020, 2, 14), Frequency.SEMI_MONTHLY}, wantErr: true,
+		},
+		{
+			name: "semi-monthly uneven end date", fields: fields{getDate(2020, 1, 1), getDate(2020, 2, 13), Frequency.SEMI_MONTHLY}, wantErr: true,
+		},
+		{
+			name: "quarterly", fields: fields{getDate(2020, 1, 1), getDate(2020, 9, 30), Frequency.QUARTERLY}, wantErr: false,
+			wantPeriods: 3, wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-04-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-07-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-09-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "half yearly", fields: fields{getDate(2020, 5, 1), getDate(2021, 4, 30), Frequency.HALF_YEARLY}, wantErr: false,
+			wantPeriods: 2, wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-10-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-11-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-04-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "half yearly uneven end date", fields: fields{getDate(2020, 5, 1), getDate(2021, 3, 31), Frequency.HALF_YEARLY}, wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -204,7 +262,7 @@ func TestConfig_SetPeriodsAndDates(t *testing.T) {
 				EndDate:   tt.fields.EndDate,
 				Frequency: tt.fields.Frequency,
 			}
-			if err := c.setPeriodsAndDates(); (err != nil) != tt.wantErr {
+			if err := c.setPeriodsAndDates(0); (err != nil) != tt.wantErr {
 				t.Fatalf("SetPeriodsAndDates() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if c.periods != tt.wantPeriods {
@@ -217,6 +275,368 @@ func TestConfig_SetPeriodsAndDates(t *testing.T) {
 	}
 }
 
+func TestConfig_SetPeriodsAndDates_FirstDueDate(t *testing.T) {
+	tests := []struct {
+		name                    string
+		endDate                 time.Time
+		firstDueDate            time.Time
+		wantErr                 error
+		wantPeriods             int64
+		wantBrokenPeriodEndDate time.Time
+		wantDates               []dateGroup
+	}{
+		{
+			name: "first due date one period after start", endDate: getDate(2022, 4, 16), firstDueDate: getDate(2020, 5, 16), wantPeriods: 24,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-04-17 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-16 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "broken period before the first period", endDate: getDate(2022, 5, 5), firstDueDate: getDate(2020, 6, 5), wantPeriods: 24,
+			wantBrokenPeriodEndDate: timeParseUtil(t, "2020-05-05 23:59:59 +0000 UTC"),
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-05-06 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-05 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-06-06 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-07-05 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "first due date too close to start", endDate: getDate(2022, 5, 5), firstDueDate: getDate(2020, 5, 5), wantErr: ErrInvalidFirstDueDate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				StartDate:    getDate(2020, 4, 17),
+				EndDate:      tt.endDate,
+				Frequency:    Frequency.MONTHLY,
+				FirstDueDate: tt.firstDueDate,
+			}
+			if err := c.setPeriodsAndDates(0); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetPeriodsAndDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.periods != tt.wantPeriods {
+				t.Fatalf("want periods: %v, got periods:%v", tt.wantPeriods, c.periods)
+			}
+			if !c.brokenPeriodEndDate.Equal(tt.wantBrokenPeriodEndDate) {
+				t.Fatalf("want broken period end date: %v, got: %v", tt.wantBrokenPeriodEndDate, c.brokenPeriodEndDate)
+			}
+			if err := areDatesEqual(c.startDates, c.endDates, tt.wantDates); err != nil {
+				t.Fatalf("dates are not equal. error:%v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_SetPeriodsAndDates_DueDayAnchor(t *testing.T) {
+	tests := []struct {
+		name                    string
+		startDate               time.Time
+		endDate                 time.Time
+		firstDueDate            time.Time
+		frequency               Frequency.Type
+		anchor                  dueday.Type
+		extendTo                int64
+		wantErr                 error
+		wantPeriods             int64
+		wantBrokenPeriodEndDate time.Time
+		wantDates               []dateGroup
+	}{
+		{
+			name: "fixed day, starting on the 31st", startDate: getDate(2020, 1, 31), endDate: getDate(2020, 4, 30), frequency: Frequency.MONTHLY,
+			anchor: dueday.FIXED_DAY, wantPeriods: 3,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-04-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "fixed day, extended beyond the end date", startDate: getDate(2020, 1, 31), endDate: getDate(2020, 4, 30), frequency: Frequency.MONTHLY,
+			anchor: dueday.FIXED_DAY, extendTo: 5, wantPeriods: 5,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-04-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "last day, starting on the 31st", startDate: getDate(2020, 1, 31), endDate: getDate(2020, 4, 30), frequency: Frequency.MONTHLY,
+			anchor: dueday.LAST_DAY, wantPeriods: 3,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-04-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-04-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "last day, starting on the 1st", startDate: getDate(2020, 1, 1), endDate: getDate(2020, 3, 31), frequency: Frequency.MONTHLY,
+			anchor: dueday.LAST_DAY, wantPeriods: 3,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-01-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-01-31 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-02-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "fixed day, quarterly", startDate: getDate(2020, 11, 30), endDate: getDate(2021, 8, 29), frequency: Frequency.QUARTERLY,
+			anchor: dueday.FIXED_DAY, wantPeriods: 3,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-11-30 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-02-28 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2021-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-05-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2021-05-30 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-08-29 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "fixed day, annually from a leap day", startDate: getDate(2020, 2, 29), endDate: getDate(2022, 2, 28), frequency: Frequency.ANNUALLY,
+			anchor: dueday.FIXED_DAY, wantPeriods: 2,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-02-29 00:00:00 +0000 UTC"), timeParseUtil(t, "2021-02-28 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2021-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2022-02-28 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "last day, broken period before the first due date", startDate: getDate(2020, 1, 10), endDate: getDate(2020, 3, 31), firstDueDate: getDate(2020, 2, 29),
+			frequency: Frequency.MONTHLY, anchor: dueday.LAST_DAY, wantPeriods: 2,
+			wantBrokenPeriodEndDate: timeParseUtil(t, "2020-01-31 23:59:59 +0000 UTC"),
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-02-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-31 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "fixed day of the first due date", startDate: getDate(2020, 1, 10), endDate: getDate(2020, 5, 30), firstDueDate: getDate(2020, 3, 30),
+			frequency: Frequency.MONTHLY, anchor: dueday.FIXED_DAY, wantPeriods: 3,
+			wantBrokenPeriodEndDate: timeParseUtil(t, "2020-02-29 23:59:59 +0000 UTC"),
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-03-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-03-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-03-31 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-04-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "first due date too close to start", startDate: getDate(2020, 1, 10), endDate: getDate(2020, 5, 30), firstDueDate: getDate(2020, 1, 30),
+			frequency: Frequency.MONTHLY, anchor: dueday.FIXED_DAY, wantErr: ErrInvalidFirstDueDate,
+		},
+		{
+			name: "last day, first due date not at the month end", startDate: getDate(2020, 1, 10), endDate: getDate(2020, 5, 31), firstDueDate: getDate(2020, 2, 28),
+			frequency: Frequency.MONTHLY, anchor: dueday.LAST_DAY, wantErr: ErrInvalidDueDayAnchor,
+		},
+		{
+			name: "uneven end date", startDate: getDate(2020, 1, 31), endDate: getDate(2020, 4, 29), frequency: Frequency.MONTHLY,
+			anchor: dueday.FIXED_DAY, wantErr: ErrUnevenEndDate,
+		},
+		{
+			name: "weekly frequency", startDate: getDate(2020, 1, 1), endDate: getDate(2020, 1, 14), frequency: Frequency.WEEKLY,
+			anchor: dueday.FIXED_DAY, wantErr: ErrInvalidDueDayAnchor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				StartDate:    tt.startDate,
+				EndDate:      tt.endDate,
+				FirstDueDate: tt.firstDueDate,
+				Frequency:    tt.frequency,
+				DueDayAnchor: tt.anchor,
+			}
+			if err := c.setPeriodsAndDates(0); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetPeriodsAndDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if err := c.extendPeriods(tt.extendTo); err != nil {
+				t.Fatalf("extendPeriods() error = %v", err)
+			}
+			if c.periods != tt.wantPeriods {
+				t.Fatalf("want periods: %v, got periods:%v", tt.wantPeriods, c.periods)
+			}
+			if !c.brokenPeriodEndDate.Equal(tt.wantBrokenPeriodEndDate) {
+				t.Fatalf("want broken period end date: %v, got: %v", tt.wantBrokenPeriodEndDate, c.brokenPeriodEndDate)
+			}
+			if err := areDatesEqual(c.startDates, c.endDates, tt.wantDates); err != nil {
+				t.Fatalf("dates are not equal. error:%v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_SetPeriodsAndDates_BusinessDay(t *testing.T) {
+	calendar := NewHolidayCalendar([]time.Weekday{time.Saturday, time.Sunday}, []time.Time{getDate(2020, 5, 14)})
+	tests := []struct {
+		name              string
+		startDate         time.Time
+		endDate           time.Time
+		frequency         Frequency.Type
+		convention        businessday.Type
+		calendar          Calendar
+		dayCount          daycount.Type
+		accrue            bool
+		wantErr           error
+		wantDates         []dateGroup
+		wantYearFractions []decimal.Decimal
+	}{
+		{
+			name: "following", startDate: getDate(2020, 4, 15), endDate: getDate(2020, 7, 14), frequency: Frequency.MONTHLY,
+			convention: businessday.FOLLOWING, calendar: calendar, dayCount: daycount.ACT_365_FIXED,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-15 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-16 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-15 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-06-16 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC")},
+			},
+			wantYearFractions: []decimal.Decimal{decimal.NewFromInt(30).Div(decimal.NewFromInt(365)), decimal.NewFromInt(31).Div(decimal.NewFromInt(365))},
+		},
+		{
+			name: "following, accruing on adjusted dates", startDate: getDate(2020, 4, 15), endDate: getDate(2020, 7, 14), frequency: Frequency.MONTHLY,
+			convention: businessday.FOLLOWING, calendar: calendar, dayCount: daycount.ACT_365_FIXED, accrue: true,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-15 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-16 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-15 23:59:59 +0000 UTC")},
+			},
+			wantYearFractions: []decimal.Decimal{decimal.NewFromInt(31).Div(decimal.NewFromInt(365)), decimal.NewFromInt(31).Div(decimal.NewFromInt(365))},
+		},
+		{
+			name: "preceding", startDate: getDate(2020, 4, 15), endDate: getDate(2020, 7, 14), frequency: Frequency.MONTHLY,
+			convention: businessday.PRECEDING, calendar: calendar,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-04-15 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-13 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-14 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-12 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-06-13 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-07-14 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "following into the next month", startDate: getDate(2020, 5, 1), endDate: getDate(2020, 7, 31), frequency: Frequency.MONTHLY,
+			convention: businessday.FOLLOWING, calendar: calendar,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-01 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-06-02 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-30 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "modified following", startDate: getDate(2020, 5, 1), endDate: getDate(2020, 7, 31), frequency: Frequency.MONTHLY,
+			convention: businessday.MODIFIED_FOLLOWING, calendar: calendar,
+			wantDates: []dateGroup{
+				{timeParseUtil(t, "2020-05-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-05-29 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-05-30 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-06-30 23:59:59 +0000 UTC")},
+				{timeParseUtil(t, "2020-07-01 00:00:00 +0000 UTC"), timeParseUtil(t, "2020-07-31 23:59:59 +0000 UTC")},
+			},
+		},
+		{
+			name: "no calendar", startDate: getDate(2020, 4, 15), endDate: getDate(2020, 7, 14), frequency: Frequency.MONTHLY,
+			convention: businessday.FOLLOWING, wantErr: ErrInvalidCalendar,
+		},
+		{
+			name: "accruing on adjusted dates without day count convention", startDate: getDate(2020, 4, 15), endDate: getDate(2020, 7, 14), frequency: Frequency.MONTHLY,
+			convention: businessday.FOLLOWING, calendar: calendar, accrue: true, wantErr: ErrInvalidBusinessDay,
+		},
+		{
+			name: "due date before the start date", startDate: getDate(2020, 5, 15), endDate: getDate(2020, 5, 18), frequency: Frequency.DAILY,
+			convention: businessday.PRECEDING, calendar: calendar, wantErr: ErrInvalidBusinessDay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				StartDate:             tt.startDate,
+				EndDate:               tt.endDate,
+				Frequency:             tt.frequency,
+				DayCountConvention:    tt.dayCount,
+				Calendar:              tt.calendar,
+				BusinessDayConvention: tt.convention,
+				AccrueOnAdjustedDates: tt.accrue,
+			}
+			if err := c.setPeriodsAndDates(0); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetPeriodsAndDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if err := areDatesEqual(c.startDates, c.endDates, tt.wantDates); err != nil {
+				t.Fatalf("dates are not equal. error:%v", err)
+			}
+			for idx, want := range tt.wantYearFractions {
+				if err := isAlmostEqual(c.yearFractions[idx], want, decimal.NewFromFloat(precision)); err != nil {
+					t.Fatalf("year fraction of period %d: %v", idx+1, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTenure(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      time.Time
+		to        time.Time
+		frequency Frequency.Type
+		want      int64
+		wantErr   error
+		wantMsg   string
+	}{
+		{name: "monthly", from: getDate(2020, 4, 15), to: getDate(2022, 4, 14), frequency: Frequency.MONTHLY, want: 24},
+		{name: "time of the day is ignored", from: time.Date(2020, 4, 15, 10, 0, 0, 0, time.UTC), to: time.Date(2022, 4, 14, 23, 59, 59, 0, time.UTC), frequency: Frequency.MONTHLY, want: 24},
+		{name: "weekly", from: getDate(2020, 1, 1), to: getDate(2020, 4, 14), frequency: Frequency.WEEKLY, want: 15},
+		{
+			name: "uneven end date", from: getDate(2020, 4, 15), to: getDate(2022, 4, 20), frequency: Frequency.MONTHLY, wantErr: ErrUnevenEndDate,
+			wantMsg: "uneven end date: 2022-04-20 is not the last day of a monthly period, 24 periods end on 2022-04-14 and 25 periods end on 2022-05-14",
+		},
+		{
+			name: "end date within the first period", from: getDate(2020, 1, 1), to: getDate(2020, 1, 5), frequency: Frequency.WEEKLY, wantErr: ErrUnevenEndDate,
+			wantMsg: "uneven end date: 2020-01-05 is before the end of the first weekly period on 2020-01-07",
+		},
+		{
+			name: "end date before the start date", from: getDate(2020, 1, 1), to: getDate(2019, 12, 31), frequency: Frequency.MONTHLY, wantErr: ErrUnevenEndDate,
+			wantMsg: "uneven end date: end date 2019-12-31 is before the start date 2020-01-01",
+		},
+		{name: "invalid frequency", from: getDate(2020, 1, 1), to: getDate(2020, 12, 31), frequency: Frequency.Type(0), wantErr: ErrInvalidFrequency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTenure(tt.from, tt.to, tt.frequency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTenure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && err.Error() != tt.wantMsg {
+				t.Fatalf("GetTenure() error message = %v, want %v", err, tt.wantMsg)
+			}
+			if got != tt.want {
+				t.Errorf("GetTenure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_GetInterestRatePerPeriodInDecimal(t *testing.T) {
+	tests := []struct {
+		name                 string
+		frequency            Frequency.Type
+		compoundingFrequency Frequency.Type
+		want                 decimal.Decimal
+	}{
+		{name: "compounded with the payments", frequency: Frequency.MONTHLY, want: decimal.NewFromFloat(0.02)},
+		{name: "same compounding frequency", frequency: Frequency.MONTHLY, compoundingFrequency: Frequency.MONTHLY, want: decimal.NewFromFloat(0.02)},
+		{name: "monthly payments, compounded annually", frequency: Frequency.MONTHLY, compoundingFrequency: Frequency.ANNUALLY, want: decimal.NewFromFloat(0.018087582483510722)},
+		{name: "monthly payments, compounded daily", frequency: Frequency.MONTHLY, compoundingFrequency: Frequency.DAILY, want: decimal.NewFromFloat(0.020194634814715284)},
+		{name: "weekly payments, compounded monthly", frequency: Frequency.WEEKLY, compoundingFrequency: Frequency.MONTHLY, want: decimal.NewFromFloat(0.004580294697583698)},
+		{name: "quarterly payments", frequency: Frequency.QUARTERLY, want: decimal.NewFromFloat(0.06)},
+		{name: "monthly payments, compounded half yearly", frequency: Frequency.MONTHLY, compoundingFrequency: Frequency.HALF_YEARLY, want: decimal.NewFromFloat(0.019067623060521344)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				Frequency:            tt.frequency,
+				CompoundingFrequency: tt.compoundingFrequency,
+				Interest:             decimal.NewFromInt(2400),
+			}
+			if err := isAlmostEqual(c.getInterestRatePerPeriodInDecimal(), tt.want, decimal.NewFromFloat(precision)); err != nil {
+				t.Fatalf("getInterestRatePerPeriodInDecimal() error: %v", err)
+			}
+		})
+	}
+}
+
 func areDatesEqual(actualStartDates []time.Time, actualEndDates []time.Time, expected []dateGroup) error {
 	for idx := range expected {
 		if !actualStartDates[idx].Equal(expected[idx].startDate) || !actualEndDates[idx].Equal(expected[idx].endDate) {
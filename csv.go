@@ -0,0 +1,225 @@
+package gofinancial
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+// defaultDateFormat is the layout of the dates in a CSV, unless specified otherwise.
+const defaultDateFormat = "2006-01-02"
+
+// csvColumns are the columns of a schedule in CSV, named after the fields of Row, in their default order.
+var csvColumns = []string{
+	"Type", "Period", "StartDate", "EndDate", "OpeningBalance", "Payment", "Interest", "Principal", "Prepayment",
+	"ClosingBalance", "Residual", "CumulativeInterest", "CumulativePrincipal",
+}
+
+// CSVOptions is used to store the format of a schedule in CSV.
+type CSVOptions struct {
+	Columns         []string       // If specified, only these columns are written, in this order, e.g. Period, EndDate and Payment
+	DateFormat      string         // Layout of the dates as per the time package, 2006-01-02 by default
+	Location        *time.Location // Location of the dates read without a time zone, UTC by default
+	PositiveAmounts bool           // If enabled, the payment, interest and principal columns, which are -ve in Row, are +ve in CSV
+}
+
+/*
+WriteCSV writes the rows as a CSV with a header of the column names, which are the names of the fields of Row. The row
+type is written as per its String method, e.g. regular, and the dates as per the date format, so the time of the day
+is left out by default.
+*/
+func WriteCSV(w io.Writer, rows []Row, opts CSVOptions) error {
+	columns, err := opts.getColumns()
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = opts.formatField(row, column)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+/*
+ReadCSV parses a schedule written by WriteCSV, or prepared elsewhere in the same format, back into rows. The first
+record is the header of the column names, in any order and with any of the columns left out. Columns left out and
+empty fields are zero in the rows.
+*/
+func ReadCSV(r io.Reader, opts CSVOptions) ([]Row, error) {
+	reader := csv.NewReader(r)
+	columns, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: no header", ErrInvalidCSV)
+	}
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		if !isCSVColumn(column) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidCSV, column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidCSV, column)
+		}
+		seen[column] = true
+	}
+	var rows []Row
+	for record := 2; ; record++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var row Row
+		for i, column := range columns {
+			if fields[i] == "" {
+				continue
+			}
+			if err := opts.parseField(&row, column, fields[i]); err != nil {
+				return nil, fmt.Errorf("%w: record %d: column %s: %v", ErrInvalidCSV, record, column, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// getColumns returns the columns to be written, i.e. all the columns unless specified.
+func (o *CSVOptions) getColumns() ([]string, error) {
+	if len(o.Columns) == 0 {
+		return csvColumns, nil
+	}
+	for _, column := range o.Columns {
+		if !isCSVColumn(column) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidCSV, column)
+		}
+	}
+	return o.Columns, nil
+}
+
+// getDateFormat returns the layout of the dates.
+func (o *CSVOptions) getDateFormat() string {
+	if o.DateFormat == "" {
+		return defaultDateFormat
+	}
+	return o.DateFormat
+}
+
+// formatField returns the value of a column of the row in CSV.
+func (o *CSVOptions) formatField(row Row, column string) string {
+	switch column {
+	case "Type":
+		return row.Type.String()
+	case "Period":
+		return strconv.FormatInt(row.Period, 10)
+	case "StartDate":
+		return row.StartDate.Format(o.getDateFormat())
+	case "EndDate":
+		return row.EndDate.Format(o.getDateFormat())
+	}
+	amount, signed := getAmountField(&row, column)
+	if signed && o.PositiveAmounts {
+		return amount.Neg().String()
+	}
+	return amount.String()
+}
+
+// parseField sets a column of the row from its value in CSV.
+func (o *CSVOptions) parseField(row *Row, column string, value string) error {
+	var err error
+	switch column {
+	case "Type":
+		row.Type, err = parseRowType(value)
+		return err
+	case "Period":
+		row.Period, err = strconv.ParseInt(value, 10, 64)
+		return err
+	case "StartDate":
+		row.StartDate, err = o.parseDate(value)
+		return err
+	case "EndDate":
+		row.EndDate, err = o.parseDate(value)
+		return err
+	}
+	amount, signed := getAmountField(row, column)
+	if *amount, err = decimal.NewFromString(value); err != nil {
+		return err
+	}
+	if signed && o.PositiveAmounts {
+		*amount = amount.Neg()
+	}
+	return nil
+}
+
+// parseDate parses a date as per the date format, in the location of the options if it has no time zone.
+func (o *CSVOptions) parseDate(value string) (time.Time, error) {
+	location := o.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return time.ParseInLocation(o.getDateFormat(), value, location)
+}
+
+// getAmountField returns the field of the row for an amount column, and whether the amounts of the column are -ve in
+// Row, like the payment.
+func getAmountField(row *Row, column string) (*decimal.Decimal, bool) {
+	switch column {
+	case "OpeningBalance":
+		return &row.OpeningBalance, false
+	case "Payment":
+		return &row.Payment, true
+	case "Interest":
+		return &row.Interest, true
+	case "Principal":
+		return &row.Principal, true
+	case "Prepayment":
+		return &row.Prepayment, true
+	case "ClosingBalance":
+		return &row.ClosingBalance, false
+	case "Residual":
+		return &row.Residual, true
+	case "CumulativeInterest":
+		return &row.CumulativeInterest, true
+	default:
+		return &row.CumulativePrincipal, true
+	}
+}
+
+// parseRowType returns the row type for its String value.
+func parseRowType(value string) (rowtype.Type, error) {
+	for t := rowtype.REGULAR; t.String() != ""; t++ {
+		if t.String() == value {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown row type %q", value)
+}
+
+// isCSVColumn returns true if the column is one of the columns of a schedule in CSV.
+func isCSVColumn(column string) bool {
+	for _, c := range csvColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package gofinancial
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+func TestWriteCSV(t *testing.T) {
+	rows := []Row{
+		{
+			Type:                rowtype.BROKEN_PERIOD,
+			Period:              0,
+			StartDate:           time.Date(2020, 4, 10, 0, 0, 0, 0, time.UTC),
+			EndDate:             time.Date(2020, 4, 14, 23, 59, 59, 0, time.UTC),
+			OpeningBalance:      decimal.NewFromInt(1000000),
+			Payment:             decimal.NewFromInt(-2630),
+			Interest:            decimal.NewFromInt(-2630),
+			ClosingBalance:      decimal.NewFromInt(1000000),
+			CumulativeInterest:  decimal.NewFromInt(-2630),
+			CumulativePrincipal: decimal.Zero,
+		},
+		{
+			Type:                rowtype.REGULAR,
+			Period:              1,
+			StartDate:           time.Date(2020, 4, 15, 0, 0, 0, 0, time.UTC),
+			EndDate:             time.Date(2020, 5, 14, 23, 59, 59, 0, time.UTC),
+			OpeningBalance:      decimal.NewFromInt(1000000),
+			Payment:             decimal.NewFromInt(-52871),
+			Interest:            decimal.NewFromInt(-20000),
+			Principal:           decimal.NewFromInt(-32871),
+			ClosingBalance:      decimal.NewFromInt(967129),
+			CumulativeInterest:  decimal.NewFromInt(-22630),
+			CumulativePrincipal: decimal.NewFromInt(-32871),
+		},
+	}
+	tests := []struct {
+		name    string
+		opts    CSVOptions
+		want    string
+		wantErr error
+	}{
+		{
+			name: "all columns",
+			opts: CSVOptions{},
+			want: "Type,Period,StartDate,EndDate,OpeningBalance,Payment,Interest,Principal,Prepayment,ClosingBalance,Residual,CumulativeInterest,CumulativePrincipal\n" +
+				"broken_period,0,2020-04-10,2020-04-14,1000000,-2630,-2630,0,0,1000000,0,-2630,0\n" +
+				"regular,1,2020-04-15,2020-05-14,1000000,-52871,-20000,-32871,0,967129,0,-22630,-32871\n",
+		},
+		{
+			name: "columns, date format and positive amounts",
+			opts: CSVOptions{Columns: []string{"Period", "EndDate", "Payment", "ClosingBalance"}, DateFormat: "02/01/2006", PositiveAmounts: true},
+			want: "Period,EndDate,Payment,ClosingBalance\n" +
+				"0,14/04/2020,2630,1000000\n" +
+				"1,14/05/2020,52871,967129\n",
+		},
+		{
+			name:    "unknown column",
+			opts:    CSVOptions{Columns: []string{"Period", "DueDate"}},
+			wantErr: ErrInvalidCSV,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := WriteCSV(&buf, rows, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WriteCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && buf.String() != tt.want {
+				t.Errorf("WriteCSV() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	a, err := NewAmortization(config)
+	if err != nil {
+		t.Fatalf("NewAmortization() call failed. error = %v", err)
+	}
+	rows, err := a.GenerateTable()
+	if err != nil {
+		t.Fatalf("GenerateTable() error = %v", err)
+	}
+	opts := CSVOptions{DateFormat: time.RFC3339, PositiveAmounts: true}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, rows, opts); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	got, err := ReadCSV(&buf, opts)
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("length mismatch of rows read, want=%v, got=%v", len(rows), len(got))
+	}
+	for i, want := range rows {
+		if err := verifyRow(t, got[i], want); err != nil {
+			t.Fatal(err)
+		}
+		if got[i].Type != want.Type || got[i].Period != want.Period || !got[i].ClosingBalance.Equal(want.ClosingBalance) ||
+			!got[i].CumulativePrincipal.Equal(want.CumulativePrincipal) {
+			t.Fatalf("row %d mismatch, want=%+v, got=%+v", i, want, got[i])
+		}
+	}
+	if err := balanceCheck(t, got, config.AmountBorrowed); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		csv     string
+		want    []Row
+		wantErr error
+	}{
+		{
+			name: "columns in any order with empty fields",
+			csv:  "Payment,Period,EndDate\n-52871,1,2020-05-14\n,2,\n",
+			want: []Row{
+				{Period: 1, EndDate: time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC), Payment: decimal.NewFromInt(-52871)},
+				{Period: 2},
+			},
+		},
+		{name: "empty", csv: "", wantErr: ErrInvalidCSV},
+		{name: "unknown column", csv: "Period,DueDate\n1,2020-05-14\n", wantErr: ErrInvalidCSV},
+		{name: "duplicate column", csv: "Period,Period\n1,1\n", wantErr: ErrInvalidCSV},
+		{name: "invalid row type", csv: "Type,Period\nemi,1\n", wantErr: ErrInvalidCSV},
+		{name: "invalid date", csv: "Period,EndDate\n1,14/05/2020\n", wantErr: ErrInvalidCSV},
+		{name: "invalid amount", csv: "Period,Payment\n1,52871 INR\n", wantErr: ErrInvalidCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCSV(strings.NewReader(tt.csv), CSVOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("length mismatch of rows read, want=%v, got=%v", len(tt.want), len(got))
+			}
+			for i, want := range tt.want {
+				if got[i].Period != want.Period || !got[i].EndDate.Equal(want.EndDate) || !got[i].Payment.Equal(want.Payment) {
+					t.Errorf("row %d mismatch, want=%+v, got=%+v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package gofinancial
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/daycount"
+)
+
+/*
+YearFraction computes the fraction of a year between two dates as per the given day count convention.
+The start date is inclusive while the end date is exclusive, and the time of the day is ignored.
+
+Params:
+
+	from		: starting date of the accrual period(inclusive)
+	to		: ending date of the accrual period(exclusive)
+	convention	: day count convention used to count the days and the days in a year
+
+References:
+
+	ISDA 2006 Definitions, Section 4.16 (Day Count Fraction).
+*/
+func YearFraction(from time.Time, to time.Time, convention daycount.Type) (decimal.Decimal, error) {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	switch convention {
+	case daycount.THIRTY_360:
+		if fd == 31 {
+			fd = 30
+		}
+		if td == 31 && fd == 30 {
+			td = 30
+		}
+		return getThirty360Fraction(fy, int(fm), fd, ty, int(tm), td), nil
+	case daycount.THIRTY_E_360:
+		if fd == 31 {
+			fd = 30
+		}
+		if td == 31 {
+			td = 30
+		}
+		return getThirty360Fraction(fy, int(fm), fd, ty, int(tm), td), nil
+	case daycount.ACT_365_FIXED:
+		return decimal.NewFromInt(int64(getDaysBetween(from, to))).Div(decimal.NewFromInt(365)), nil
+	case daycount.ACT_360:
+		return decimal.NewFromInt(int64(getDaysBetween(from, to))).Div(decimal.NewFromInt(360)), nil
+	case daycount.ACT_ACT_ISDA:
+		// days falling in each calendar year are divided by the number of days in that year.
+		result := decimal.Zero
+		start := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+		end := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+		for start.Before(end) {
+			nextYear := time.Date(start.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+			if nextYear.After(end) {
+				nextYear = end
+			}
+			yearLength := getDaysBetween(time.Date(start.Year(), 1, 1, 0, 0, 0, 0, time.UTC), time.Date(start.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC))
+			days := getDaysBetween(start, nextYear)
+			result = result.Add(decimal.NewFromInt(int64(days)).Div(decimal.NewFromInt(int64(yearLength))))
+			start = nextYear
+		}
+		return result, nil
+	default:
+		return decimal.Zero, fmt.Errorf("%w: unknown day count convention %d", ErrInvalidDayCount, convention)
+	}
+}
+
+// getThirty360Fraction returns the year fraction assuming 30 days in every month and 360 days in a year,
+// for days that are already adjusted as per the convention.
+func getThirty360Fraction(fy int, fm int, fd int, ty int, tm int, td int) decimal.Decimal {
+	days := 360*(ty-fy) + 30*(tm-fm) + (td - fd)
+	return decimal.NewFromInt(int64(days)).Div(decimal.NewFromInt(360))
+}
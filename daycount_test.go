@@ -0,0 +1,47 @@
+package gofinancial
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/daycount"
+)
+
+func TestYearFraction(t *testing.T) {
+	type args struct {
+		from       time.Time
+		to         time.Time
+		convention daycount.Type
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    decimal.Decimal
+		wantErr error
+	}{
+		{"30/360, half year", args{getDate(2007, 1, 15), getDate(2007, 7, 15), daycount.THIRTY_360}, decimal.NewFromFloat(0.5), nil},
+		{"30/360, end date on 31st", args{getDate(2007, 1, 15), getDate(2007, 3, 31), daycount.THIRTY_360}, decimal.NewFromInt(76).Div(decimal.NewFromInt(360)), nil},
+		{"30/360, start and end date on 31st", args{getDate(2007, 1, 31), getDate(2007, 3, 31), daycount.THIRTY_360}, decimal.NewFromInt(60).Div(decimal.NewFromInt(360)), nil},
+		{"30E/360, end date on 31st", args{getDate(2007, 1, 15), getDate(2007, 3, 31), daycount.THIRTY_E_360}, decimal.NewFromInt(75).Div(decimal.NewFromInt(360)), nil},
+		{"30E/360, across february", args{getDate(2007, 8, 31), getDate(2008, 2, 29), daycount.THIRTY_E_360}, decimal.NewFromInt(179).Div(decimal.NewFromInt(360)), nil},
+		{"act/365 fixed, leap year", args{getDate(2020, 1, 1), getDate(2021, 1, 1), daycount.ACT_365_FIXED}, decimal.NewFromInt(366).Div(decimal.NewFromInt(365)), nil},
+		{"act/360", args{getDate(2020, 1, 1), getDate(2020, 7, 1), daycount.ACT_360}, decimal.NewFromInt(182).Div(decimal.NewFromInt(360)), nil},
+		{"act/act isda, across years", args{getDate(2019, 12, 1), getDate(2020, 3, 1), daycount.ACT_ACT_ISDA}, decimal.NewFromInt(31).Div(decimal.NewFromInt(365)).Add(decimal.NewFromInt(60).Div(decimal.NewFromInt(366))), nil},
+		{"act/act isda, leap year", args{getDate(2020, 1, 1), getDate(2021, 1, 1), daycount.ACT_ACT_ISDA}, decimal.NewFromInt(1), nil},
+		{"invalid convention", args{getDate(2020, 1, 1), getDate(2021, 1, 1), daycount.Type(0)}, decimal.Zero, ErrInvalidDayCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := YearFraction(tt.args.from, tt.args.to, tt.args.convention)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("YearFraction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := isAlmostEqual(got, tt.want, decimal.NewFromFloat(precision)); err != nil {
+				t.Errorf("error:%v, YearFraction() = %v, want %v", err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package gofinancial
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/razorpay/go-financial/enums/dueday"
+	"github.com/razorpay/go-financial/enums/frequency"
+)
+
+// setAnchoredDates sets the periods and dates of a schedule with due dates anchored to a day of the month as per
+// Config.DueDayAnchor, for the given start and end dates without the time of the day, or for the given number of
+// periods if the tenure is not zero.
+//
+// With FIXED_DAY, the periods are due on the day of the month of the first due date if specified, or else on the day
+// before the day of the month of the start date, clamped to the end of shorter months, e.g. a loan starting on
+// 31 January is due on 29 February, 30 March and 30 April. With LAST_DAY, the periods are due on the last day of every
+// month. Every period starts on the day after the previous one is due.
+func (c *Config) setAnchoredDates(startDate time.Time, endDate time.Time, tenure int64) error {
+	if c.DueDayAnchor.String() == "" {
+		return fmt.Errorf("%w: unknown due day anchor %d", ErrInvalidDueDayAnchor, c.DueDayAnchor)
+	}
+	months := getMonthsPerPeriod(c.Frequency)
+	if months == 0 {
+		return fmt.Errorf("%w: due dates cannot be anchored for %v frequency", ErrInvalidDueDayAnchor, c.Frequency)
+	}
+	location := c.StartDate.Location()
+	firstDate := c.StartDate
+	if !c.FirstDueDate.IsZero() {
+		dy, dm, dd := c.FirstDueDate.Date()
+		dueDate := time.Date(dy, dm, dd, 23, 59, 59, 0, location)
+		if c.DueDayAnchor == dueday.FIXED_DAY {
+			c.dueDay = dd
+		} else if !dueDate.Equal(getDueDateInMonth(dy, dm, 0, location)) {
+			return fmt.Errorf("%w: first due date %v is not the last day of a month", ErrInvalidDueDayAnchor, c.FirstDueDate)
+		}
+		c.firstAnchoredDueDate = dueDate
+		// the first regular period ends on the first due date.
+		previous := c.getAnchoredDueDate(-1)
+		regularStartDate := time.Date(previous.Year(), previous.Month(), previous.Day()+1, 0, 0, 0, 0, location)
+		if regularStartDate.Before(startDate) {
+			return fmt.Errorf("%w: first regular period ending on %v starts on %v, before the start date %v", ErrInvalidFirstDueDate, c.FirstDueDate, regularStartDate, startDate)
+		}
+		if regularStartDate.After(startDate) {
+			c.brokenPeriodEndDate = previous
+			firstDate = regularStartDate
+		}
+	} else {
+		// the first period is due in the month in which it would have ended without the anchoring.
+		month := startDate.Month() + time.Month(months)
+		if startDate.Day() == 1 {
+			month--
+		}
+		if c.DueDayAnchor == dueday.FIXED_DAY {
+			c.dueDay = startDate.Day() - 1
+		}
+		c.firstAnchoredDueDate = getDueDateInMonth(startDate.Year(), month, c.dueDay, location)
+	}
+
+	for i := 0; ; i++ {
+		dueDate := c.getAnchoredDueDate(i)
+		if i == 0 {
+			c.startDates = append(c.startDates, firstDate)
+		} else {
+			previous := c.endDates[i-1]
+			c.startDates = append(c.startDates, time.Date(previous.Year(), previous.Month(), previous.Day()+1, 0, 0, 0, 0, location))
+		}
+		c.endDates = append(c.endDates, dueDate)
+		if tenure != 0 {
+			if int64(len(c.endDates)) == tenure {
+				break
+			}
+			continue
+		}
+		dy, dm, dd := dueDate.Date()
+		if due := time.Date(dy, dm, dd, 0, 0, 0, 0, endDate.Location()); !due.Before(endDate) {
+			if !due.Equal(endDate) {
+				return fmt.Errorf("%w: %v is not a due date, the next one is %v", ErrUnevenEndDate, endDate, dueDate)
+			}
+			break
+		}
+	}
+	c.periods = int64(len(c.endDates))
+	return nil
+}
+
+// getAnchoredDueDate returns the unadjusted due date of the period at the given index, counting from 0 for the first
+// regular period, when the due dates are anchored to a day of the month.
+func (c *Config) getAnchoredDueDate(index int) time.Time {
+	months := time.Month(index * getMonthsPerPeriod(c.Frequency))
+	return getDueDateInMonth(c.firstAnchoredDueDate.Year(), c.firstAnchoredDueDate.Month()+months, c.dueDay, c.firstAnchoredDueDate.Location())
+}
+
+// getDueDateInMonth returns the given day of the month at the end of the day, or the last day of the month if the day
+// is 0 or beyond the end of the month. The month is normalised like time.Date, e.g. month 13 is January of next year.
+func getDueDateInMonth(year int, month time.Month, day int, location *time.Location) time.Time {
+	// day 0 of the next month is the last day of this month.
+	lastDate := time.Date(year, month+1, 0, 23, 59, 59, 0, location)
+	if day == 0 || day > lastDate.Day() {
+		return lastDate
+	}
+	return time.Date(lastDate.Year(), lastDate.Month(), day, 23, 59, 59, 0, location)
+}
+
+// getMonthsPerPeriod returns the number of months in a period of the given frequency, or 0 if the periods are not
+// whole months.
+func getMonthsPerPeriod(freq frequency.Type) int {
+	switch freq {
+	case frequency.MONTHLY:
+		return 1
+	case frequency.QUARTERLY:
+		return 3
+	case frequency.HALF_YEARLY:
+		return 6
+	case frequency.ANNUALLY:
+		return 12
+	default:
+		return 0
+	}
+}
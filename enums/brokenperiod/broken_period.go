@@ -0,0 +1,19 @@
+package brokenperiod
+
+type Type uint8
+
+const (
+	UPFRONT Type = iota + 1
+	ADD_TO_FIRST_INSTALLMENT
+	CAPITALISE
+)
+
+var toString = map[Type]string{
+	UPFRONT:                  "upfront",
+	ADD_TO_FIRST_INSTALLMENT: "add_to_first_installment",
+	CAPITALISE:               "capitalise",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
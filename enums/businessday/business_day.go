@@ -0,0 +1,19 @@
+package businessday
+
+type Type uint8
+
+const (
+	FOLLOWING Type = iota + 1
+	MODIFIED_FOLLOWING
+	PRECEDING
+)
+
+var toString = map[Type]string{
+	FOLLOWING:          "following",
+	MODIFIED_FOLLOWING: "modified_following",
+	PRECEDING:          "preceding",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
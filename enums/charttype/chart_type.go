@@ -0,0 +1,21 @@
+package charttype
+
+type Type uint8
+
+const (
+	STACKED_BAR Type = iota + 1
+	BALANCE_LINE
+	CUMULATIVE_AREA
+	SPLIT_PIE
+)
+
+var toString = map[Type]string{
+	STACKED_BAR:     "stacked_bar",
+	BALANCE_LINE:    "balance_line",
+	CUMULATIVE_AREA: "cumulative_area",
+	SPLIT_PIE:       "split_pie",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -0,0 +1,23 @@
+package daycount
+
+type Type uint8
+
+const (
+	THIRTY_360 Type = iota + 1
+	THIRTY_E_360
+	ACT_365_FIXED
+	ACT_360
+	ACT_ACT_ISDA
+)
+
+var toString = map[Type]string{
+	THIRTY_360:    "thirty_360",
+	THIRTY_E_360:  "thirty_e_360",
+	ACT_365_FIXED: "act_365_fixed",
+	ACT_360:       "act_360",
+	ACT_ACT_ISDA:  "act_act_isda",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -0,0 +1,17 @@
+package dueday
+
+type Type uint8
+
+const (
+	FIXED_DAY Type = iota + 1
+	LAST_DAY
+)
+
+var toString = map[Type]string{
+	FIXED_DAY: "fixed_day",
+	LAST_DAY:  "last_day",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -7,16 +7,40 @@ const (
 	WEEKLY
 	MONTHLY
 	ANNUALLY
+	BIWEEKLY
+	SEMI_MONTHLY
+	QUARTERLY
+	HALF_YEARLY
 )
 
-// TODO: check if this assumption is ok
+// toValue is the number of periods in a year, from which the rate of interest for a period is derived. A day count
+// convention can be used to account for the actual length of every period instead.
 var toValue = map[Type]int{
-	DAILY:    365,
-	WEEKLY:   52,
-	MONTHLY:  12,
-	ANNUALLY: 1,
+	DAILY:        365,
+	WEEKLY:       52,
+	MONTHLY:      12,
+	ANNUALLY:     1,
+	BIWEEKLY:     26,
+	SEMI_MONTHLY: 24,
+	QUARTERLY:    4,
+	HALF_YEARLY:  2,
+}
+
+var toString = map[Type]string{
+	DAILY:        "daily",
+	WEEKLY:       "weekly",
+	MONTHLY:      "monthly",
+	ANNUALLY:     "annually",
+	BIWEEKLY:     "biweekly",
+	SEMI_MONTHLY: "semi_monthly",
+	QUARTERLY:    "quarterly",
+	HALF_YEARLY:  "half_yearly",
 }
 
 func (t *Type) Value() int {
 	return toValue[*t]
 }
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -5,11 +5,17 @@ type Type uint8
 const (
 	FLAT Type = iota + 1
 	REDUCING
+	BULLET
+	EQUAL_PRINCIPAL
+	RULE_OF_78
 )
 
 var toString = map[Type]string{
-	FLAT:     "flat",
-	REDUCING: "reducing",
+	FLAT:            "flat",
+	REDUCING:        "reducing",
+	BULLET:          "bullet",
+	EQUAL_PRINCIPAL: "equal_principal",
+	RULE_OF_78:      "rule_of_78",
 }
 
 func (t Type) String() string {
@@ -0,0 +1,17 @@
+package moratoriumtype
+
+type Type uint8
+
+const (
+	CAPITALISE Type = iota + 1
+	INTEREST_ONLY
+)
+
+var toString = map[Type]string{
+	CAPITALISE:    "capitalise",
+	INTEREST_ONLY: "interest_only",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -12,6 +12,15 @@ var value = map[Type]int64{
 	ENDING:    0,
 }
 
+var toString = map[Type]string{
+	BEGINNING: "beginning",
+	ENDING:    "ending",
+}
+
 func (t Type) Value() int64 {
 	return value[t]
 }
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -0,0 +1,17 @@
+package prepaymentstrategy
+
+type Type uint8
+
+const (
+	REDUCE_EMI Type = iota + 1
+	REDUCE_TENURE
+)
+
+var toString = map[Type]string{
+	REDUCE_EMI:    "reduce_emi",
+	REDUCE_TENURE: "reduce_tenure",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -0,0 +1,17 @@
+package resetstrategy
+
+type Type uint8
+
+const (
+	KEEP_TENURE Type = iota + 1
+	KEEP_EMI
+)
+
+var toString = map[Type]string{
+	KEEP_TENURE: "keep_tenure",
+	KEEP_EMI:    "keep_emi",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
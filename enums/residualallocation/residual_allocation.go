@@ -0,0 +1,21 @@
+package residualallocation
+
+type Type uint8
+
+const (
+	ADJUST_LAST_INSTALLMENT Type = iota + 1
+	ADJUST_FIRST_INSTALLMENT
+	SPREAD
+	ADJUST_INTEREST
+)
+
+var toString = map[Type]string{
+	ADJUST_LAST_INSTALLMENT:  "adjust_last_installment",
+	ADJUST_FIRST_INSTALLMENT: "adjust_first_installment",
+	SPREAD:                   "spread",
+	ADJUST_INTEREST:          "adjust_interest",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
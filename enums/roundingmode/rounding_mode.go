@@ -0,0 +1,25 @@
+package roundingmode
+
+type Type uint8
+
+const (
+	HALF_UP Type = iota + 1
+	HALF_EVEN
+	UP
+	DOWN
+	CEILING
+	FLOOR
+)
+
+var toString = map[Type]string{
+	HALF_UP:   "half_up",
+	HALF_EVEN: "half_even",
+	UP:        "up",
+	DOWN:      "down",
+	CEILING:   "ceiling",
+	FLOOR:     "floor",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
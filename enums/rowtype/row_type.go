@@ -0,0 +1,19 @@
+package rowtype
+
+type Type uint8
+
+const (
+	REGULAR Type = iota + 1
+	BROKEN_PERIOD
+	MORATORIUM
+)
+
+var toString = map[Type]string{
+	REGULAR:       "regular",
+	BROKEN_PERIOD: "broken_period",
+	MORATORIUM:    "moratorium",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
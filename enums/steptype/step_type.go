@@ -0,0 +1,17 @@
+package steptype
+
+type Type uint8
+
+const (
+	PERCENTAGE Type = iota + 1
+	AMOUNT
+)
+
+var toString = map[Type]string{
+	PERCENTAGE: "percentage",
+	AMOUNT:     "amount",
+}
+
+func (t Type) String() string {
+	return toString[t]
+}
@@ -0,0 +1,48 @@
+package gofinancial
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+)
+
+// EqualPrincipal implements financial methods for facilitating a loan use case, in which the same principal is repaid
+// every period along with the interest on the reducing balance, so that the payments decline over the tenure.
+type EqualPrincipal struct{}
+
+// GetPrincipal returns principal amount contribution in a given period towards a loan, depending on config.
+func (e *EqualPrincipal) GetPrincipal(config Config, period int64) decimal.Decimal {
+	dPeriod := decimal.NewFromInt(config.periods)
+	minusOne := decimal.NewFromInt(-1)
+	// balloon amount, if any, is repaid along with the final payment.
+	return config.AmountBorrowed.Sub(config.getBalloonAmount()).Div(dPeriod).Mul(minusOne)
+}
+
+// GetInterest returns interest amount contribution in a given period towards a loan, depending on config.
+func (e *EqualPrincipal) GetInterest(config Config, period int64) decimal.Decimal {
+	accrualPeriod := period
+	if config.PaymentPeriod == paymentperiod.BEGINNING {
+		// the payment at the beginning of a period pays the interest accrued during the previous period.
+		if period == 1 {
+			return decimal.Zero
+		}
+		accrualPeriod = period - 1
+	}
+	// the interest accrues on the balance after the payments of the earlier periods, i.e. after the payment made at the
+	// beginning of the accrual period too. adding principal coz it is -ve.
+	repaid := e.GetPrincipal(config, period).Mul(decimal.NewFromInt(period - 1))
+	balance := config.AmountBorrowed.Add(repaid)
+	minusOne := decimal.NewFromInt(-1)
+	return balance.Mul(config.getInterestRateForPeriod(accrualPeriod)).Mul(minusOne)
+}
+
+// GetPayment returns the payment to be done for a loan depending on config, i.e. the payment of the first period,
+// which is the highest.
+func (e *EqualPrincipal) GetPayment(config Config) decimal.Decimal {
+	return e.GetPaymentForPeriod(config, 1)
+}
+
+// GetPaymentForPeriod returns the payment to be done in a given period, depending on config.
+func (e *EqualPrincipal) GetPaymentForPeriod(config Config, period int64) decimal.Decimal {
+	return e.GetPrincipal(config, period).Add(e.GetInterest(config, period))
+}
@@ -3,10 +3,33 @@ package gofinancial
 import "errors"
 
 var (
-	ErrPayment          = errors.New("payment not matching interest plus principal")
-	ErrUnevenEndDate    = errors.New("uneven end date")
-	ErrInvalidFrequency = errors.New("invalid frequency")
-	ErrNotEqual         = errors.New("input values are not equal")
-	ErrOutOfBounds      = errors.New("error in representing data as it is out of bounds")
-	ErrTolerence        = errors.New("nan error as tolerence level exceeded")
+	ErrPayment              = errors.New("payment not matching interest plus principal")
+	ErrUnevenEndDate        = errors.New("uneven end date")
+	ErrUnevenStartDate      = errors.New("uneven start date")
+	ErrInvalidFrequency     = errors.New("invalid frequency")
+	ErrNotEqual             = errors.New("input values are not equal")
+	ErrOutOfBounds          = errors.New("error in representing data as it is out of bounds")
+	ErrTolerence            = errors.New("nan error as tolerence level exceeded")
+	ErrNoSignChange         = errors.New("values must contain at least one positive and one negative value")
+	ErrInvalidPrepayment    = errors.New("invalid prepayment")
+	ErrInvalidDayCount      = errors.New("invalid day count convention")
+	ErrInvalidFirstDueDate  = errors.New("invalid first due date")
+	ErrInvalidMoratorium    = errors.New("invalid moratorium")
+	ErrInvalidRateReset     = errors.New("invalid rate reset")
+	ErrInvalidBalloonAmount = errors.New("invalid balloon amount")
+	ErrInvalidStep          = errors.New("invalid step")
+	ErrInvalidCalendar      = errors.New("invalid business day calendar")
+	ErrInvalidBusinessDay   = errors.New("invalid business day convention")
+	ErrInvalidDueDayAnchor  = errors.New("invalid due day anchor")
+	ErrInvalidTenure        = errors.New("invalid tenure")
+	ErrInvalidConfig        = errors.New("invalid config")
+	ErrInvalidDate          = errors.New("invalid date")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrInvalidInterest      = errors.New("invalid interest")
+	ErrInvalidInterestType  = errors.New("invalid interest type")
+	ErrInvalidPaymentPeriod = errors.New("invalid payment period")
+	ErrInvalidRounding      = errors.New("invalid rounding")
+	ErrInvalidBrokenPeriod  = errors.New("invalid broken period interest")
+	ErrInvalidCSV           = errors.New("invalid csv")
+	ErrInvalidChartType     = errors.New("invalid chart type")
 )
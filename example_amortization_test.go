@@ -1,6 +1,7 @@
 package gofinancial_test
 
 import (
+	"os"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -57,128 +58,235 @@ func ExampleAmortization_GenerateTable() {
 	if err != nil {
 		panic(err)
 	}
-	gofinancial.PrintRows(rows)
+	if err := gofinancial.WriteRows(os.Stdout, rows); err != nil {
+		panic(err)
+	}
 	// Output:
 	// [
 	//	{
+	//		"Type": 1,
 	//		"Period": 1,
 	//		"StartDate": "2009-11-11T04:30:00+05:30",
 	//		"EndDate": "2010-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "200000000",
 	//		"Payment": "-29364848",
 	//		"Interest": "-24000000",
-	//		"Principal": "-5364848"
+	//		"Principal": "-5364848",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "194635152",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-24000000",
+	//		"CumulativePrincipal": "-5364848"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 2,
 	//		"StartDate": "2010-11-11T00:00:00+05:30",
 	//		"EndDate": "2011-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "194635152",
 	//		"Payment": "-29364848",
 	//		"Interest": "-23356218",
-	//		"Principal": "-6008630"
+	//		"Principal": "-6008630",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "188626522",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-47356218",
+	//		"CumulativePrincipal": "-11373478"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 3,
 	//		"StartDate": "2011-11-11T00:00:00+05:30",
 	//		"EndDate": "2012-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "188626522",
 	//		"Payment": "-29364848",
 	//		"Interest": "-22635183",
-	//		"Principal": "-6729665"
+	//		"Principal": "-6729665",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "181896857",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-69991401",
+	//		"CumulativePrincipal": "-18103143"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 4,
 	//		"StartDate": "2012-11-11T00:00:00+05:30",
 	//		"EndDate": "2013-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "181896857",
 	//		"Payment": "-29364848",
 	//		"Interest": "-21827623",
-	//		"Principal": "-7537225"
+	//		"Principal": "-7537225",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "174359632",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-91819024",
+	//		"CumulativePrincipal": "-25640368"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 5,
 	//		"StartDate": "2013-11-11T00:00:00+05:30",
 	//		"EndDate": "2014-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "174359632",
 	//		"Payment": "-29364848",
 	//		"Interest": "-20923156",
-	//		"Principal": "-8441692"
+	//		"Principal": "-8441692",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "165917940",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-112742180",
+	//		"CumulativePrincipal": "-34082060"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 6,
 	//		"StartDate": "2014-11-11T00:00:00+05:30",
 	//		"EndDate": "2015-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "165917940",
 	//		"Payment": "-29364848",
 	//		"Interest": "-19910153",
-	//		"Principal": "-9454695"
+	//		"Principal": "-9454695",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "156463245",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-132652333",
+	//		"CumulativePrincipal": "-43536755"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 7,
 	//		"StartDate": "2015-11-11T00:00:00+05:30",
 	//		"EndDate": "2016-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "156463245",
 	//		"Payment": "-29364848",
 	//		"Interest": "-18775589",
-	//		"Principal": "-10589259"
+	//		"Principal": "-10589259",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "145873986",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-151427922",
+	//		"CumulativePrincipal": "-54126014"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 8,
 	//		"StartDate": "2016-11-11T00:00:00+05:30",
 	//		"EndDate": "2017-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "145873986",
 	//		"Payment": "-29364848",
 	//		"Interest": "-17504878",
-	//		"Principal": "-11859970"
+	//		"Principal": "-11859970",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "134014016",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-168932800",
+	//		"CumulativePrincipal": "-65985984"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 9,
 	//		"StartDate": "2017-11-11T00:00:00+05:30",
 	//		"EndDate": "2018-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "134014016",
 	//		"Payment": "-29364848",
 	//		"Interest": "-16081682",
-	//		"Principal": "-13283166"
+	//		"Principal": "-13283166",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "120730850",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-185014482",
+	//		"CumulativePrincipal": "-79269150"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 10,
 	//		"StartDate": "2018-11-11T00:00:00+05:30",
 	//		"EndDate": "2019-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "120730850",
 	//		"Payment": "-29364848",
 	//		"Interest": "-14487702",
-	//		"Principal": "-14877146"
+	//		"Principal": "-14877146",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "105853704",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-199502184",
+	//		"CumulativePrincipal": "-94146296"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 11,
 	//		"StartDate": "2019-11-11T00:00:00+05:30",
 	//		"EndDate": "2020-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "105853704",
 	//		"Payment": "-29364848",
 	//		"Interest": "-12702445",
-	//		"Principal": "-16662403"
+	//		"Principal": "-16662403",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "89191301",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-212204629",
+	//		"CumulativePrincipal": "-110808699"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 12,
 	//		"StartDate": "2020-11-11T00:00:00+05:30",
 	//		"EndDate": "2021-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "89191301",
 	//		"Payment": "-29364848",
 	//		"Interest": "-10702956",
-	//		"Principal": "-18661892"
+	//		"Principal": "-18661892",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "70529409",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-222907585",
+	//		"CumulativePrincipal": "-129470591"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 13,
 	//		"StartDate": "2021-11-11T00:00:00+05:30",
 	//		"EndDate": "2022-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "70529409",
 	//		"Payment": "-29364848",
 	//		"Interest": "-8463529",
-	//		"Principal": "-20901319"
+	//		"Principal": "-20901319",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "49628090",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-231371114",
+	//		"CumulativePrincipal": "-150371910"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 14,
 	//		"StartDate": "2022-11-11T00:00:00+05:30",
 	//		"EndDate": "2023-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "49628090",
 	//		"Payment": "-29364848",
 	//		"Interest": "-5955371",
-	//		"Principal": "-23409477"
+	//		"Principal": "-23409477",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "26218613",
+	//		"Residual": "0",
+	//		"CumulativeInterest": "-237326485",
+	//		"CumulativePrincipal": "-173781387"
 	//	},
 	//	{
+	//		"Type": 1,
 	//		"Period": 15,
 	//		"StartDate": "2023-11-11T00:00:00+05:30",
 	//		"EndDate": "2024-11-10T23:59:59+05:30",
+	//		"OpeningBalance": "26218613",
 	//		"Payment": "-29364847",
 	//		"Interest": "-3146234",
-	//		"Principal": "-26218613"
+	//		"Principal": "-26218613",
+	//		"Prepayment": "0",
+	//		"ClosingBalance": "0",
+	//		"Residual": "1",
+	//		"CumulativeInterest": "-240472719",
+	//		"CumulativePrincipal": "-200000000"
 	//	}
 	// ]
 }
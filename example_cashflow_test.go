@@ -0,0 +1,48 @@
+package gofinancial_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	gofinancial "github.com/razorpay/go-financial"
+)
+
+// An investment of 10,000 made on 1st Jan 2008 returns 2750, 4250, 3250 and 2750 on irregular dates.
+// What is the net present value of the cash flow at a discount rate of 9% p.a. ?
+func ExampleXnpv() {
+	flows := []gofinancial.CashFlow{
+		{Date: time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(-10000)},
+		{Date: time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(2750)},
+		{Date: time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(4250)},
+		{Date: time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(3250)},
+		{Date: time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(2750)},
+	}
+	xnpv, err := gofinancial.Xnpv(decimal.NewFromFloat(0.09), flows)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("xnpv:%v", xnpv.Round(2))
+	// Output:
+	// xnpv:2086.65
+}
+
+// An investment of 10,000 made on 1st Jan 2008 returns 2750, 4250, 3250 and 2750 on irregular dates.
+// What is the annual rate of return of the investment ?
+func ExampleXirr() {
+	flows := []gofinancial.CashFlow{
+		{Date: time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(-10000)},
+		{Date: time.Date(2008, 3, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(2750)},
+		{Date: time.Date(2008, 10, 30, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(4250)},
+		{Date: time.Date(2009, 2, 15, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(3250)},
+		{Date: time.Date(2009, 4, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(2750)},
+	}
+	xirr, err := gofinancial.Xirr(flows, 100, decimal.NewFromFloat(1e-7), decimal.NewFromFloat(0.1))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("xirr:%v", xirr.Round(4))
+	// Output:
+	// xirr:0.3734
+}
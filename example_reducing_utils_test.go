@@ -194,3 +194,31 @@ func ExampleNper() {
 	// Output:
 	// nper:27
 }
+
+// Given an initial deposit of 40000 followed by withdrawls of 5000, 8000, 12000 and 30000.
+// What is the rate of return at which the net present value of the cash flow is zero ?
+func ExampleIrr() {
+	values := []decimal.Decimal{decimal.NewFromInt(-40000), decimal.NewFromInt(5000), decimal.NewFromInt(8000), decimal.NewFromInt(12000), decimal.NewFromInt(30000)}
+	irr, err := gofinancial.Irr(values, 100, decimal.NewFromFloat(1e-7), decimal.NewFromFloat(0.1))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("irr:%v", irr.Round(4))
+	// Output:
+	// irr:0.1058
+}
+
+// An investment of 1,20,000 returns 39000, 30000, 21000, 37000 and 46000 over the next five years.
+// If the investment is financed at 10% and the returns are reinvested at 12%, what is the modified internal rate of return ?
+func ExampleMirr() {
+	values := []decimal.Decimal{decimal.NewFromInt(-120000), decimal.NewFromInt(39000), decimal.NewFromInt(30000), decimal.NewFromInt(21000), decimal.NewFromInt(37000), decimal.NewFromInt(46000)}
+	financeRate := decimal.NewFromFloat(0.1)
+	reinvestRate := decimal.NewFromFloat(0.12)
+	mirr, err := gofinancial.Mirr(values, financeRate, reinvestRate)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("mirr:%v", mirr.Round(4))
+	// Output:
+	// mirr:0.1261
+}
@@ -0,0 +1,21 @@
+package gofinancial_test
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	gofinancial "github.com/razorpay/go-financial"
+)
+
+// A 12 month consumer loan following the Rule of 78 charges a total interest of 780.
+// If it is settled right after the 3rd installment, how much of the interest is rebated ?
+func ExampleRuleOf78Rebate() {
+	rebate, err := gofinancial.RuleOf78Rebate(decimal.NewFromInt(780), 3, 12)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("rebate:%v", rebate)
+	// Output:
+	// rebate:450
+}
@@ -8,3 +8,16 @@ type Financial interface {
 	GetInterest(config Config, period int64) decimal.Decimal
 	GetPayment(config Config) decimal.Decimal
 }
+
+// VariablePayment is implemented by the financial use cases in which the payment is not the same in every period.
+type VariablePayment interface {
+	GetPaymentForPeriod(config Config, period int64) decimal.Decimal
+}
+
+// getPaymentForPeriod returns the payment to be done in a given period, depending on config.
+func getPaymentForPeriod(f Financial, config Config, period int64) decimal.Decimal {
+	if v, ok := f.(VariablePayment); ok {
+		return v.GetPaymentForPeriod(config, period)
+	}
+	return f.GetPayment(config)
+}
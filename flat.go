@@ -6,23 +6,40 @@ import "github.com/shopspring/decimal"
 type Flat struct{}
 
 // GetPrincipal returns principal amount contribution in a given period towards a loan, depending on config.
-func (f *Flat) GetPrincipal(config Config, _ int64) decimal.Decimal {
+func (f *Flat) GetPrincipal(config Config, period int64) decimal.Decimal {
+	if config.yearFractions != nil {
+		// payment is fixed, so the principal varies with the interest accrued in the period.
+		return f.GetPayment(config).Sub(f.GetInterest(config, period))
+	}
 	dPeriod := decimal.NewFromInt(config.periods)
 	minusOne := decimal.NewFromInt(-1)
-	return config.AmountBorrowed.Div(dPeriod).Mul(minusOne)
+	// balloon amount, if any, is repaid along with the final payment.
+	return config.AmountBorrowed.Sub(config.getBalloonAmount()).Div(dPeriod).Mul(minusOne)
 }
 
 // GetInterest returns interest amount contribution in a given period towards a loan, depending on config.
 func (f *Flat) GetInterest(config Config, period int64) decimal.Decimal {
 	minusOne := decimal.NewFromInt(-1)
-	return config.getInterestRatePerPeriodInDecimal().Mul(config.AmountBorrowed).Mul(minusOne)
+	return config.getInterestRateForPeriod(period).Mul(config.AmountBorrowed).Mul(minusOne)
 }
 
 // GetPayment returns the periodic payment to be done for a loan depending on config.
 func (f *Flat) GetPayment(config Config) decimal.Decimal {
 	dPeriod := decimal.NewFromInt(config.periods)
 	minusOne := decimal.NewFromInt(-1)
-	totalInterest := config.getInterestRatePerPeriodInDecimal().Mul(dPeriod).Mul(config.AmountBorrowed)
-	Payment := totalInterest.Add(config.AmountBorrowed).Mul(minusOne).Div(dPeriod)
+	Payment := getTotalFlatInterest(config).Add(config.AmountBorrowed).Sub(config.getBalloonAmount()).Mul(minusOne).Div(dPeriod)
 	return Payment
 }
+
+// getTotalFlatInterest returns the total interest charged on the amount borrowed over all the periods, at a flat rate.
+func getTotalFlatInterest(config Config) decimal.Decimal {
+	if config.yearFractions == nil {
+		dPeriod := decimal.NewFromInt(config.periods)
+		return config.getInterestRatePerPeriodInDecimal().Mul(dPeriod).Mul(config.AmountBorrowed)
+	}
+	totalInterest := decimal.Zero
+	for i := int64(1); i <= config.periods; i++ {
+		totalInterest = totalInterest.Add(config.getInterestRateForPeriod(i).Mul(config.AmountBorrowed))
+	}
+	return totalInterest
+}
@@ -0,0 +1,212 @@
+package gofinancial
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// JSONOptions is used to store the format of a schedule in JSON.
+type JSONOptions struct {
+	PositiveAmounts   bool   // If enabled, the payment, interest and principal of the rows, which are -ve in Row as seen by the borrower, are +ve as seen by the lender
+	DecimalsAsNumbers bool   // If enabled, the amounts are JSON numbers instead of strings, which may lose precision in some decoders
+	Indent            string // If specified, the JSON is indented with it, e.g. a tab
+}
+
+// jsonDecimal is an amount in JSON, encoded as a string unless number is set.
+type jsonDecimal struct {
+	value  decimal.Decimal
+	number bool
+}
+
+func (d jsonDecimal) MarshalJSON() ([]byte, error) {
+	if d.number {
+		return []byte(d.value.String()), nil
+	}
+	return json.Marshal(d.value.String())
+}
+
+type jsonSchedule struct {
+	Config jsonConfig `json:"config"`
+	Rows   []jsonRow  `json:"rows"`
+}
+
+type jsonConfig struct {
+	StartDate              time.Time        `json:"start_date"`
+	EndDate                time.Time        `json:"end_date"`
+	Frequency              string           `json:"frequency"`
+	CompoundingFrequency   string           `json:"compounding_frequency,omitempty"`
+	AmountBorrowed         jsonDecimal      `json:"amount_borrowed"`
+	InterestType           string           `json:"interest_type"`
+	Interest               jsonDecimal      `json:"interest"`
+	PaymentPeriod          string           `json:"payment_period,omitempty"`
+	EnableRounding         bool             `json:"enable_rounding"`
+	RoundingPlaces         int32            `json:"rounding_places"`
+	RoundingMode           string           `json:"rounding_mode,omitempty"`
+	RoundingIncrement      jsonDecimal      `json:"rounding_increment"`
+	RoundingErrorTolerance jsonDecimal      `json:"rounding_error_tolerance"`
+	ResidualAllocation     string           `json:"residual_allocation,omitempty"`
+	Prepayments            []jsonPrepayment `json:"prepayments,omitempty"`
+	PrepaymentStrategy     string           `json:"prepayment_strategy,omitempty"`
+	DayCountConvention     string           `json:"day_count_convention,omitempty"`
+	FirstDueDate           *time.Time       `json:"first_due_date,omitempty"`
+	BrokenPeriodInterest   string           `json:"broken_period_interest,omitempty"`
+	Moratorium             *jsonMoratorium  `json:"moratorium,omitempty"`
+	RateResets             []jsonRateReset  `json:"rate_resets,omitempty"`
+	RateResetStrategy      string           `json:"rate_reset_strategy,omitempty"`
+	BalloonAmount          jsonDecimal      `json:"balloon_amount"`
+	Step                   *jsonStep        `json:"step,omitempty"`
+	BusinessDayConvention  string           `json:"business_day_convention,omitempty"`
+	AccrueOnAdjustedDates  bool             `json:"accrue_on_adjusted_dates,omitempty"`
+	DueDayAnchor           string           `json:"due_day_anchor,omitempty"`
+}
+
+type jsonPrepayment struct {
+	Period int64       `json:"period,omitempty"`
+	Date   *time.Time  `json:"date,omitempty"`
+	Amount jsonDecimal `json:"amount"`
+}
+
+type jsonMoratorium struct {
+	Periods int64  `json:"periods"`
+	Type    string `json:"type,omitempty"`
+}
+
+type jsonRateReset struct {
+	Date     time.Time   `json:"date"`
+	Interest jsonDecimal `json:"interest"`
+}
+
+type jsonStep struct {
+	Periods int64       `json:"periods"`
+	Type    string      `json:"type,omitempty"`
+	Value   jsonDecimal `json:"value"`
+}
+
+type jsonRow struct {
+	Type                string      `json:"type"`
+	Period              int64       `json:"period"`
+	StartDate           time.Time   `json:"start_date"`
+	EndDate             time.Time   `json:"end_date"`
+	OpeningBalance      jsonDecimal `json:"opening_balance"`
+	Payment             jsonDecimal `json:"payment"`
+	Interest            jsonDecimal `json:"interest"`
+	Principal           jsonDecimal `json:"principal"`
+	Prepayment          jsonDecimal `json:"prepayment"`
+	ClosingBalance      jsonDecimal `json:"closing_balance"`
+	Residual            jsonDecimal `json:"residual"`
+	CumulativeInterest  jsonDecimal `json:"cumulative_interest"`
+	CumulativePrincipal jsonDecimal `json:"cumulative_principal"`
+}
+
+/*
+MarshalSchedule returns the JSON of a schedule, i.e. an object with the config of the amortization as config and the
+rows as rows. The fields are in snake_case, the enums are encoded as per their String method and the dates in RFC 3339
+format. Unlike WriteRows, the field names do not change with the Go fields of Config and Row.
+*/
+func (a Amortization) MarshalSchedule(rows []Row, opts JSONOptions) ([]byte, error) {
+	schedule := jsonSchedule{Config: opts.getConfig(a.Config), Rows: make([]jsonRow, 0, len(rows))}
+	for _, row := range rows {
+		schedule.Rows = append(schedule.Rows, opts.getRow(row))
+	}
+	if opts.Indent != "" {
+		return json.MarshalIndent(schedule, "", opts.Indent)
+	}
+	return json.Marshal(schedule)
+}
+
+// WriteJSON writes the JSON of a schedule, as returned by MarshalSchedule, to the writer.
+func (a Amortization) WriteJSON(w io.Writer, rows []Row, opts JSONOptions) error {
+	bytes, err := a.MarshalSchedule(rows, opts)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bytes)
+	return err
+}
+
+// getConfig returns the JSON of a config. The amounts of the config are +ve irrespective of the sign convention.
+func (o *JSONOptions) getConfig(c *Config) jsonConfig {
+	config := jsonConfig{
+		StartDate:              c.StartDate,
+		EndDate:                c.EndDate,
+		Frequency:              c.Frequency.String(),
+		CompoundingFrequency:   c.CompoundingFrequency.String(),
+		AmountBorrowed:         o.getDecimal(c.AmountBorrowed),
+		InterestType:           c.InterestType.String(),
+		Interest:               o.getDecimal(c.Interest),
+		PaymentPeriod:          c.PaymentPeriod.String(),
+		EnableRounding:         c.EnableRounding,
+		RoundingPlaces:         c.RoundingPlaces,
+		RoundingMode:           c.RoundingMode.String(),
+		RoundingIncrement:      o.getDecimal(c.RoundingIncrement),
+		RoundingErrorTolerance: o.getDecimal(c.RoundingErrorTolerance),
+		ResidualAllocation:     c.ResidualAllocation.String(),
+		PrepaymentStrategy:     c.PrepaymentStrategy.String(),
+		DayCountConvention:     c.DayCountConvention.String(),
+		FirstDueDate:           getOptionalTime(c.FirstDueDate),
+		BrokenPeriodInterest:   c.BrokenPeriodInterest.String(),
+		RateResetStrategy:      c.RateResetStrategy.String(),
+		BalloonAmount:          o.getDecimal(c.BalloonAmount),
+		BusinessDayConvention:  c.BusinessDayConvention.String(),
+		AccrueOnAdjustedDates:  c.AccrueOnAdjustedDates,
+		DueDayAnchor:           c.DueDayAnchor.String(),
+	}
+	for _, prepayment := range c.Prepayments {
+		config.Prepayments = append(config.Prepayments, jsonPrepayment{
+			Period: prepayment.Period,
+			Date:   getOptionalTime(prepayment.Date),
+			Amount: o.getDecimal(prepayment.Amount),
+		})
+	}
+	if c.Moratorium.Periods > 0 {
+		config.Moratorium = &jsonMoratorium{Periods: c.Moratorium.Periods, Type: c.Moratorium.Type.String()}
+	}
+	for _, reset := range c.RateResets {
+		config.RateResets = append(config.RateResets, jsonRateReset{Date: reset.Date, Interest: o.getDecimal(reset.Interest)})
+	}
+	if c.hasSteps() {
+		config.Step = &jsonStep{Periods: c.Step.Periods, Type: c.Step.Type.String(), Value: o.getDecimal(c.Step.Value)}
+	}
+	return config
+}
+
+// getRow returns the JSON of a row, as per the sign convention.
+func (o *JSONOptions) getRow(row Row) jsonRow {
+	amount := func(column string) jsonDecimal {
+		value, signed := getAmountField(&row, column)
+		if signed && o.PositiveAmounts {
+			return o.getDecimal(value.Neg())
+		}
+		return o.getDecimal(*value)
+	}
+	return jsonRow{
+		Type:                row.Type.String(),
+		Period:              row.Period,
+		StartDate:           row.StartDate,
+		EndDate:             row.EndDate,
+		OpeningBalance:      amount("OpeningBalance"),
+		Payment:             amount("Payment"),
+		Interest:            amount("Interest"),
+		Principal:           amount("Principal"),
+		Prepayment:          amount("Prepayment"),
+		ClosingBalance:      amount("ClosingBalance"),
+		Residual:            amount("Residual"),
+		CumulativeInterest:  amount("CumulativeInterest"),
+		CumulativePrincipal: amount("CumulativePrincipal"),
+	}
+}
+
+func (o *JSONOptions) getDecimal(value decimal.Decimal) jsonDecimal {
+	return jsonDecimal{value: value, number: o.DecimalsAsNumbers}
+}
+
+// getOptionalTime returns nil for a zero time, so that it is left out of the JSON.
+func getOptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,116 @@
+package gofinancial
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+)
+
+func TestAmortization_MarshalSchedule(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	config.PaymentPeriod = paymentperiod.ENDING
+	config.Prepayments = []Prepayment{{Period: 12, Amount: decimal.NewFromInt(100000)}}
+	a, err := NewAmortization(config)
+	if err != nil {
+		t.Fatalf("NewAmortization() call failed. error = %v", err)
+	}
+	rows, err := a.GenerateTable()
+	if err != nil {
+		t.Fatalf("GenerateTable() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		opts       JSONOptions
+		wantConfig map[string]interface{}
+		wantRow    map[string]interface{}
+	}{
+		{
+			name: "borrower view with decimals as strings",
+			opts: JSONOptions{},
+			wantConfig: map[string]interface{}{
+				"start_date":      "2020-04-15T00:00:00Z",
+				"frequency":       "monthly",
+				"amount_borrowed": "1000000",
+				"interest_type":   "reducing",
+				"interest":        "2400",
+				"payment_period":  "ending",
+				"rounding_places": json.Number("0"),
+				"prepayments":     []interface{}{map[string]interface{}{"period": json.Number("12"), "amount": "100000"}},
+			},
+			wantRow: map[string]interface{}{
+				"type":            "regular",
+				"period":          json.Number("1"),
+				"end_date":        "2020-05-14T23:59:59Z",
+				"payment":         "-52871",
+				"interest":        "-20000",
+				"principal":       "-32871",
+				"closing_balance": "967129",
+			},
+		},
+		{
+			name: "lender view with decimals as numbers",
+			opts: JSONOptions{PositiveAmounts: true, DecimalsAsNumbers: true, Indent: "\t"},
+			wantConfig: map[string]interface{}{
+				"amount_borrowed": json.Number("1000000"),
+				"interest":        json.Number("2400"),
+			},
+			wantRow: map[string]interface{}{
+				"payment":              json.Number("52871"),
+				"principal":            json.Number("32871"),
+				"closing_balance":      json.Number("967129"),
+				"cumulative_principal": json.Number("32871"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := a.WriteJSON(&buf, rows, tt.opts); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			if got := strings.HasPrefix(buf.String(), "{\n\t"); got != (tt.opts.Indent != "") {
+				t.Fatalf("indentation mismatch, got=%q", buf.String()[:3])
+			}
+			decoder := json.NewDecoder(&buf)
+			decoder.UseNumber()
+			var got struct {
+				Config map[string]interface{}   `json:"config"`
+				Rows   []map[string]interface{} `json:"rows"`
+			}
+			if err := decoder.Decode(&got); err != nil {
+				t.Fatalf("error decoding the schedule: %v", err)
+			}
+			if len(got.Rows) != len(rows) {
+				t.Fatalf("length mismatch of rows, want=%v, got=%v", len(rows), len(got.Rows))
+			}
+			for key, want := range tt.wantConfig {
+				if gotJSON, wantJSON := toJSON(t, got.Config[key]), toJSON(t, want); gotJSON != wantJSON {
+					t.Errorf("config.%s = %s, want %s", key, gotJSON, wantJSON)
+				}
+			}
+			for key, want := range tt.wantRow {
+				if gotJSON, wantJSON := toJSON(t, got.Rows[0][key]), toJSON(t, want); gotJSON != wantJSON {
+					t.Errorf("rows[0].%s = %s, want %s", key, gotJSON, wantJSON)
+				}
+			}
+			if _, ok := got.Config["moratorium"]; ok {
+				t.Errorf("config.moratorium is not omitted")
+			}
+		})
+	}
+}
+
+func toJSON(t *testing.T, value interface{}) string {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("error encoding %v: %v", value, err)
+	}
+	return string(bytes)
+}
@@ -0,0 +1,64 @@
+package gofinancial
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/moratoriumtype"
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+// Moratorium represents the first few periods of a loan in which no principal is repaid.
+//
+// During a moratorium the interest of every period accrues on the outstanding principal and is either capitalised,
+// i.e. added to the outstanding principal with nothing paid, or paid as it accrues. The outstanding principal at the
+// end of the moratorium is then amortised over the remaining periods.
+type Moratorium struct {
+	Periods int64               // Number of periods under moratorium, starting from the first period
+	Type    moratoriumtype.Type // Moratorium type enum with CAPITALISE(default) or INTEREST_ONLY value
+}
+
+// validateMoratorium returns an error if the moratorium does not leave any period for repayment.
+func (c *Config) validateMoratorium() error {
+	if c.Moratorium.Periods < 0 || c.Moratorium.Periods >= c.periods {
+		return fmt.Errorf("%w: %d periods of moratorium leave no period for repayment in a schedule of %d periods", ErrInvalidMoratorium, c.Moratorium.Periods, c.periods)
+	}
+	return nil
+}
+
+// getMoratoriumRows appends the rows for the periods under moratorium to the given rows, resetting the rate of
+// interest of the config as per the given rate resets.
+func (a Amortization) getMoratoriumRows(rows []Row, resets map[int64]decimal.Decimal) []Row {
+	for i := int64(1); i <= a.Config.Moratorium.Periods; i++ {
+		if rate, ok := resets[i]; ok {
+			a.Config.Interest = rate
+		}
+		row := Row{
+			Type:      rowtype.MORATORIUM,
+			Period:    i,
+			StartDate: a.Config.startDates[i-1],
+			EndDate:   a.Config.endDates[i-1],
+		}
+		outstanding := a.Config.AmountBorrowed
+		if len(rows) > 0 {
+			outstanding = rows[len(rows)-1].ClosingBalance
+		}
+		minusOne := decimal.NewFromInt(-1)
+		interest := outstanding.Mul(a.Config.getInterestRateForPeriod(i)).Mul(minusOne)
+		if a.Config.EnableRounding {
+			interest = a.Config.round(interest)
+		}
+		row.Interest = interest
+		switch a.Config.Moratorium.Type {
+		case moratoriumtype.INTEREST_ONLY:
+			row.Payment = interest
+		default:
+			// principal is +ve as the interest is added to the outstanding principal.
+			row.Principal = interest.Neg()
+		}
+		setBalancesAndTotals(&row, rows, a.Config.AmountBorrowed)
+		rows = append(rows, row)
+	}
+	return rows
+}
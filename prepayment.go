@@ -0,0 +1,114 @@
+package gofinancial
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+	"github.com/razorpay/go-financial/enums/prepaymentstrategy"
+)
+
+// Prepayment represents a part-payment made towards the outstanding principal along with the payment of a period.
+//
+// After a prepayment, the outstanding principal is re-amortised over the remaining periods. For REDUCING interest
+// this yields a lower EMI or fewer periods depending on Config.PrepaymentStrategy, while for FLAT interest the
+// outstanding principal is treated as a new flat rate loan, i.e. flat interest is charged on the reduced principal
+// from the next period onwards.
+type Prepayment struct {
+	Period int64           // Period in which the prepayment is made. If zero, the period containing Date is used.
+	Date   time.Time       // Date on which the prepayment is made, used only if Period is not specified.
+	Amount decimal.Decimal // Amount prepaid, capped to the outstanding principal
+}
+
+// getPrepayments returns the total amount prepaid in every period that has a prepayment.
+func (c *Config) getPrepayments() (map[int64]decimal.Decimal, error) {
+	result := make(map[int64]decimal.Decimal)
+	for _, prepayment := range c.Prepayments {
+		period := prepayment.Period
+		if period == 0 {
+			period = c.getPeriodForDate(prepayment.Date)
+		}
+		if period < 1 || period > c.periods {
+			return nil, fmt.Errorf("%w: no period found for prepayment of %v on %v", ErrInvalidPrepayment, prepayment.Amount, prepayment.Date)
+		}
+		if period <= c.Moratorium.Periods {
+			return nil, fmt.Errorf("%w: period %d is under moratorium", ErrInvalidPrepayment, period)
+		}
+		amount := prepayment.Amount
+		if c.EnableRounding {
+			amount = c.round(amount)
+		}
+		result[period] = result[period].Add(amount)
+	}
+	return result, nil
+}
+
+// validatePrepaymentPeriods returns an error for the first prepayment after the last period of the schedule, which is
+// never made as the schedule ends early, e.g. after a foreclosure or a prepayment reducing the tenure.
+func validatePrepaymentPeriods(prepayments map[int64]decimal.Decimal, lastPeriod int64) error {
+	first := int64(0)
+	for period := range prepayments {
+		if period > lastPeriod && (first == 0 || period < first) {
+			first = period
+		}
+	}
+	if first > 0 {
+		return fmt.Errorf("%w: period %d is after the schedule ends in period %d", ErrInvalidPrepayment, first, lastPeriod)
+	}
+	return nil
+}
+
+// getPeriodForDate returns the period whose start and end dates contain the given date, or 0 if there is none.
+func (c *Config) getPeriodForDate(date time.Time) int64 {
+	for i := range c.startDates {
+		if !date.Before(c.startDates[i]) && !date.After(c.endDates[i]) {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+// getSegment returns the config used to (re-)amortise the outstanding principal over the given number of periods,
+// starting after the period given by offset.
+func (c Config) getSegment(offset int64, outstanding decimal.Decimal, periods int64) Config {
+	segment := c
+	segment.AmountBorrowed = outstanding
+	segment.periods = periods
+	segment.periodOffset = c.periodOffset + offset
+	segment.startDates = c.startDates[offset : offset+periods]
+	segment.endDates = c.endDates[offset : offset+periods]
+	if c.yearFractions != nil {
+		segment.yearFractions = c.yearFractions[offset : offset+periods]
+	}
+	segment.schedule = &reducingSchedule{}
+	if offset > 0 {
+		// the outstanding principal is measured right after a payment, so the next payment is due after a full period.
+		segment.PaymentPeriod = paymentperiod.ENDING
+	}
+	return segment
+}
+
+// reamortise returns the config for the periods following a prepayment made in the given period, depending on the
+// prepayment strategy. The payment that would have been due in the next period is needed to keep the EMI unchanged
+// while reducing the tenure.
+func (a Amortization) reamortise(current decimal.Decimal, period int64, lastPeriod int64, outstanding decimal.Decimal) Config {
+	remaining := lastPeriod - period
+	// the principal of a bullet loan is repaid in the final period, so its tenure is never reduced.
+	if a.Config.PrepaymentStrategy != prepaymentstrategy.REDUCE_TENURE || a.Config.InterestType == interesttype.BULLET {
+		return a.Config.getSegment(period, outstanding, remaining)
+	}
+	// reduce tenure: find the least number of periods for which the payment does not exceed the current one.
+	payment := current.Abs()
+	periods := sort.Search(int(remaining), func(idx int) bool {
+		segment := a.Config.getSegment(period, outstanding, int64(idx+1))
+		return a.Financial.GetPayment(segment).Abs().LessThanOrEqual(payment)
+	}) + 1
+	if int64(periods) > remaining {
+		periods = int(remaining)
+	}
+	return a.Config.getSegment(period, outstanding, int64(periods))
+}
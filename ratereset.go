@@ -0,0 +1,90 @@
+package gofinancial
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/resetstrategy"
+)
+
+// maxTenureInYears limits how far a schedule is extended to keep the EMI unchanged after a rate reset.
+const maxTenureInYears = 50
+
+// RateReset represents a change in the rate of interest of a floating rate loan.
+//
+// The new rate applies from the first period that starts on or after the effective date, and the outstanding principal
+// is re-amortised from that period onwards. Depending on Config.RateResetStrategy, either the tenure is kept unchanged
+// and the EMI changes, or the EMI is kept unchanged and the tenure changes, which may extend the schedule beyond
+// Config.EndDate.
+type RateReset struct {
+	Date     time.Time       // Date from which the new rate is effective
+	Interest decimal.Decimal // New rate of interest in basis points
+}
+
+// getRateResets returns the new rate of interest for every period in which the rate is reset. If there are multiple
+// resets for a period, the one with the latest effective date is used.
+func (c *Config) getRateResets() (map[int64]decimal.Decimal, error) {
+	resets := make(map[int64]RateReset)
+	for _, reset := range c.RateResets {
+		period := c.getPeriodStartingFrom(reset.Date)
+		if period == 0 {
+			return nil, fmt.Errorf("%w: no period starts on or after %v", ErrInvalidRateReset, reset.Date)
+		}
+		if existing, ok := resets[period]; !ok || !reset.Date.Before(existing.Date) {
+			resets[period] = reset
+		}
+	}
+	result := make(map[int64]decimal.Decimal)
+	for period, reset := range resets {
+		result[period] = reset.Interest
+	}
+	return result, nil
+}
+
+// getPeriodStartingFrom returns the first period that starts on or after the given date, or 0 if there is none.
+func (c *Config) getPeriodStartingFrom(date time.Time) int64 {
+	for i := range c.startDates {
+		if !c.startDates[i].Before(date) {
+			return int64(i + 1)
+		}
+	}
+	return 0
+}
+
+// resetRate returns the config for the periods from the given period onwards, after the rate of interest is reset at
+// the start of the period, depending on the rate reset strategy. The payment that would have been due in the period
+// is needed to keep the EMI unchanged while changing the tenure, for which the schedule is extended beyond the end date
+// if required.
+func (a Amortization) resetRate(current decimal.Decimal, period int64, lastPeriod int64, outstanding decimal.Decimal) (Config, error) {
+	paid := period - 1
+	remaining := lastPeriod - paid
+	// the principal of a bullet loan is repaid in the final period, so its tenure is never changed.
+	if a.Config.RateResetStrategy != resetstrategy.KEEP_EMI || a.Config.InterestType == interesttype.BULLET {
+		return a.Config.getSegment(paid, outstanding, remaining), nil
+	}
+	// keep emi: find the least number of periods for which the payment does not exceed the current one.
+	payment := current.Abs()
+	maxPeriods := int64(maxTenureInYears*a.Config.Frequency.Value()) - paid
+	limit := remaining
+	for a.Financial.GetPayment(a.Config.getSegment(paid, outstanding, limit)).Abs().GreaterThan(payment) {
+		if limit >= maxPeriods {
+			return Config{}, fmt.Errorf("%w: payment of %v cannot repay %v at %v basis points", ErrInvalidRateReset, payment, outstanding, a.Config.Interest)
+		}
+		limit *= 2
+		if limit > maxPeriods {
+			limit = maxPeriods
+		}
+		if err := a.Config.extendPeriods(paid + limit); err != nil {
+			return Config{}, err
+		}
+	}
+	periods := sort.Search(int(limit), func(idx int) bool {
+		segment := a.Config.getSegment(paid, outstanding, int64(idx+1))
+		return a.Financial.GetPayment(segment).Abs().LessThanOrEqual(payment)
+	}) + 1
+	return a.Config.getSegment(paid, outstanding, int64(periods)), nil
+}
@@ -1,21 +1,122 @@
 package gofinancial
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+)
 
 // Reducing implements financial methods for facilitating a loan use case, following a reducing rate of interest.
 type Reducing struct{}
 
 // GetPrincipal returns principal amount contribution in a given period towards a loan, depending on config.
 func (r *Reducing) GetPrincipal(config Config, period int64) decimal.Decimal {
-	return PPmt(config.getInterestRatePerPeriodInDecimal(), period, config.periods, config.AmountBorrowed, decimal.Zero, config.PaymentPeriod)
+	if config.yearFractions != nil || config.hasSteps() {
+		return r.GetPaymentForPeriod(config, period).Sub(r.GetInterest(config, period))
+	}
+	return PPmt(config.getInterestRatePerPeriodInDecimal(), period, config.periods, config.AmountBorrowed, config.getBalloonAmount().Neg(), config.PaymentPeriod)
 }
 
 // GetInterest returns interest amount contribution in a given period towards a loan, depending on config.
 func (r *Reducing) GetInterest(config Config, period int64) decimal.Decimal {
-	return IPmt(config.getInterestRatePerPeriodInDecimal(), period, config.periods, config.AmountBorrowed, decimal.Zero, config.PaymentPeriod)
+	if config.yearFractions != nil || config.hasSteps() {
+		return r.getInterests(config)[period-1]
+	}
+	return IPmt(config.getInterestRatePerPeriodInDecimal(), period, config.periods, config.AmountBorrowed, config.getBalloonAmount().Neg(), config.PaymentPeriod)
 }
 
-// GetPayment returns the periodic payment to be done for a loan depending on config.
+// GetPayment returns the periodic payment to be done for a loan depending on config. If a balloon amount is specified,
+// it is the future value left outstanding after the last payment. If steps are specified, it is the payment of the
+// first period. If a day count convention is specified, the payment is solved as per the rate of every period, so that
+// the loan is amortised by the last payment instead of leaving the difference to it.
 func (r *Reducing) GetPayment(config Config) decimal.Decimal {
-	return Pmt(config.getInterestRatePerPeriodInDecimal(), config.periods, config.AmountBorrowed, decimal.Zero, config.PaymentPeriod)
+	if config.yearFractions != nil || config.hasSteps() {
+		if config.schedule == nil {
+			return config.getStartingEmi().Neg()
+		}
+		if !config.schedule.solved {
+			config.schedule.emi = config.getStartingEmi()
+			config.schedule.solved = true
+		}
+		return config.schedule.emi.Neg()
+	}
+	return Pmt(config.getInterestRatePerPeriodInDecimal(), config.periods, config.AmountBorrowed, config.getBalloonAmount().Neg(), config.PaymentPeriod)
+}
+
+// GetPaymentForPeriod returns the payment to be done in a given period, which changes periodically if steps are
+// specified.
+func (r *Reducing) GetPaymentForPeriod(config Config, period int64) decimal.Decimal {
+	return r.getPayments(config)(period)
+}
+
+// getPayments returns a function giving the payment of every period, so that the starting EMI of a stepped schedule
+// is solved, and the stepped EMIs of a segment are computed, only once.
+func (r *Reducing) getPayments(config Config) func(period int64) decimal.Decimal {
+	payment := r.GetPayment(config)
+	if !config.hasSteps() {
+		return func(period int64) decimal.Decimal {
+			return payment
+		}
+	}
+	if config.schedule == nil {
+		return func(period int64) decimal.Decimal {
+			return config.getSteppedEmi(payment.Neg(), period).Neg()
+		}
+	}
+	if config.schedule.payments == nil {
+		payments := make([]decimal.Decimal, config.periods)
+		for i := range payments {
+			payments[i] = config.getSteppedEmi(payment.Neg(), int64(i+1)).Neg()
+		}
+		config.schedule.payments = payments
+	}
+	payments := config.schedule.payments
+	return func(period int64) decimal.Decimal {
+		return payments[period-1]
+	}
+}
+
+// reducingSchedule holds what is solved for all the periods of a segment at once, when the payment or the interest of a
+// period depends on the periods before it, i.e. if steps or a day count convention are specified. It is filled on first
+// use and shared by the copies of the segment, so that every row of the segment only indexes into it.
+type reducingSchedule struct {
+	emi       decimal.Decimal   // starting EMI(+ve), if solved
+	solved    bool              // true if the starting EMI is solved
+	payments  []decimal.Decimal // payment of every period, if steps are specified and the EMIs are stepped
+	interests []decimal.Decimal // interest of every period, if accrued
+}
+
+// getInterests returns the interest of every period of the config, accrued only once for a segment.
+func (r *Reducing) getInterests(config Config) []decimal.Decimal {
+	if config.schedule == nil {
+		return getAccruedInterests(config, r.getPayments(config))
+	}
+	if config.schedule.interests == nil {
+		config.schedule.interests = getAccruedInterests(config, r.getPayments(config))
+	}
+	return config.schedule.interests
+}
+
+// getAccruedInterests returns the interest paid in every period, when the payments are known but the interest accrues
+// on the outstanding principal as per the rate for each period. Any principal left due to the varying rates is
+// settled in the final period.
+func getAccruedInterests(config Config, payments func(period int64) decimal.Decimal) []decimal.Decimal {
+	places := int32(decimal.DivisionPrecision)
+	balance := config.AmountBorrowed
+	accrued := decimal.Zero
+	interests := make([]decimal.Decimal, config.periods)
+	for i := int64(1); i <= config.periods; i++ {
+		if config.PaymentPeriod == paymentperiod.BEGINNING {
+			// the payment at the beginning of a period pays the interest accrued during the previous period.
+			interests[i-1] = accrued.Neg()
+			// adding payment coz it is -ve.
+			balance = balance.Add(payments(i)).Add(accrued).Round(places)
+			accrued = balance.Mul(config.getInterestRateForPeriod(i)).Round(places)
+		} else {
+			accrued = balance.Mul(config.getInterestRateForPeriod(i)).Round(places)
+			interests[i-1] = accrued.Neg()
+			balance = balance.Add(payments(i)).Add(accrued).Round(places)
+		}
+	}
+	return interests
 }
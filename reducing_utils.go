@@ -221,7 +221,12 @@ func Fv(rate decimal.Decimal, nper int64, pmt decimal.Decimal, pv decimal.Decima
 	dNper := decimal.NewFromInt(nper)
 
 	factor := one.Add(rate).Pow(dNper)
-	secondFactor := factor.Sub(one).Mul(one.Add(dRateWithWhen)).Div(rate)
+	var secondFactor decimal.Decimal
+	if rate.Equal(decimal.Zero) {
+		secondFactor = dNper
+	} else {
+		secondFactor = factor.Sub(one).Mul(one.Add(dRateWithWhen)).Div(rate)
+	}
 
 	return pv.Mul(factor).Add(pmt.Mul(secondFactor)).Mul(minusOne)
 }
@@ -263,7 +268,12 @@ func Pv(rate decimal.Decimal, nper int64, pmt decimal.Decimal, fv decimal.Decima
 	dRateWithWhen := rate.Mul(dWhen)
 
 	factor := one.Add(rate).Pow(dNper)
-	secondFactor := factor.Sub(one).Mul(one.Add(dRateWithWhen)).Div(rate)
+	var secondFactor decimal.Decimal
+	if rate.Equal(decimal.Zero) {
+		secondFactor = dNper
+	} else {
+		secondFactor = factor.Sub(one).Mul(one.Add(dRateWithWhen)).Div(rate)
+	}
 
 	return fv.Add(pmt.Mul(secondFactor)).Div(factor).Mul(minusOne)
 }
@@ -291,6 +301,115 @@ func Npv(rate decimal.Decimal, values []decimal.Decimal) decimal.Decimal {
 	return internalNpv
 }
 
+/*
+Irr computes the Internal Rate of Return, i.e. the rate at which the net present value of a cash flow series is zero,
+by running Newton Rapson on:
+ npv = sum(values[t] / (1+rate)**t) == 0
+
+Params:
+
+ values		: the value of the cash flow for each time period, starting with the initial investment.
+		  There must be at least one positive and one negative value.
+ maxIter 	: total number of iterations to perform calculation
+ tolerance 	: accept result only if the difference in iteration values is less than the tolerance provided
+ initialGuess 	: an initial point to start approximating from
+
+References:
+	L. J. Gitman, “Principles of Managerial Finance, Brief,” 3rd ed., Addison-Wesley, 2003, pg. 348.
+*/
+func Irr(values []decimal.Decimal, maxIter int64, tolerance, initialGuess decimal.Decimal) (decimal.Decimal, error) {
+	if !hasSignChange(values) {
+		return decimal.Zero, ErrNoSignChange
+	}
+	var nextIterRate, currentIterRate decimal.Decimal = initialGuess, initialGuess
+
+	for iter := int64(0); iter < maxIter; iter++ {
+		currentIterRate = nextIterRate
+		ratio, err := getIrrRatio(values, currentIterRate)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		nextIterRate = currentIterRate.Sub(ratio)
+		// skip further loops if |nextIterRate-currentIterRate| < tolerance
+		if nextIterRate.Sub(currentIterRate).Abs().LessThan(tolerance) {
+			break
+		}
+	}
+
+	if nextIterRate.Sub(currentIterRate).Abs().GreaterThanOrEqual(tolerance) {
+		return decimal.Zero, ErrTolerence
+	}
+	return nextIterRate, nil
+}
+
+// getIrrRatio returns npv(rate)/npv'(rate), the newton step used by Irr.
+func getIrrRatio(values []decimal.Decimal, rate decimal.Decimal) (decimal.Decimal, error) {
+	one := decimal.NewFromInt(1)
+	factor := one.Add(rate)
+	if factor.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, ErrTolerence
+	}
+	npv := decimal.Zero
+	derivative := decimal.Zero
+	discount := one
+	for t, value := range values {
+		// npv += value / (1+rate)**t
+		npv = npv.Add(value.Div(discount))
+		discount = discount.Mul(factor)
+		// derivative += -t * value / (1+rate)**(t+1)
+		derivative = derivative.Sub(decimal.NewFromInt(int64(t)).Mul(value).Div(discount))
+	}
+	if derivative.IsZero() {
+		return decimal.Zero, ErrTolerence
+	}
+	return npv.Div(derivative), nil
+}
+
+/*
+Mirr computes the Modified Internal Rate of Return, which considers both the cost of the investment and the interest
+received on reinvestment of the cash flows.
+
+Params:
+
+ values		: the value of the cash flow for each time period, starting with the initial investment.
+		  There must be at least one positive and one negative value.
+ financeRate	: interest rate paid on the cash flows (negative values)
+ reinvestRate	: interest rate received on the cash flows upon reinvestment (positive values)
+
+References:
+	L. J. Gitman, “Principles of Managerial Finance, Brief,” 3rd ed., Addison-Wesley, 2003, pg. 348.
+*/
+func Mirr(values []decimal.Decimal, financeRate decimal.Decimal, reinvestRate decimal.Decimal) (decimal.Decimal, error) {
+	if !hasSignChange(values) {
+		return decimal.Zero, ErrNoSignChange
+	}
+	one := decimal.NewFromInt(1)
+	positives := make([]decimal.Decimal, len(values))
+	negatives := make([]decimal.Decimal, len(values))
+	for i, value := range values {
+		if value.IsPositive() {
+			positives[i] = value
+		} else {
+			negatives[i] = value
+		}
+	}
+	numerator := Npv(reinvestRate, positives).Abs()
+	denominator := Npv(financeRate, negatives).Abs()
+	floatRatio, _ := numerator.Div(denominator).Float64()
+	root := decimal.NewFromFloat(math.Pow(floatRatio, 1/float64(len(values)-1)))
+	return root.Mul(one.Add(reinvestRate)).Sub(one), nil
+}
+
+// hasSignChange reports whether values contain at least one positive and one negative value.
+func hasSignChange(values []decimal.Decimal) bool {
+	var positive, negative bool
+	for _, value := range values {
+		positive = positive || value.IsPositive()
+		negative = negative || value.IsNegative()
+	}
+	return positive && negative
+}
+
 /*
 This function computes the ratio that is used to find a single value that sets the non-liner equation to zero
 
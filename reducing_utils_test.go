@@ -71,6 +71,16 @@ func Test_Fv(t *testing.T) {
 			},
 			want: decimal.NewFromFloat(15692.928894335893),
 		},
+		{
+			name: "zero rate", args: args{
+				rate: decimal.Zero,
+				nper: 10 * 12,
+				pmt:  decimal.NewFromInt(-100),
+				pv:   decimal.NewFromInt(-100),
+				when: paymentperiod.ENDING,
+			},
+			want: decimal.NewFromInt(12100),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -261,6 +271,15 @@ func Test_Pv(t *testing.T) {
 				when: paymentperiod.ENDING,
 			},
 			want: decimal.NewFromFloat(2384.1091906934976),
+		}, {
+			name: "zero rate", args: args{
+				rate: decimal.Zero,
+				nper: 1 * 12,
+				pmt:  decimal.NewFromInt(-300),
+				fv:   decimal.NewFromInt(1000),
+				when: paymentperiod.ENDING,
+			},
+			want: decimal.NewFromInt(2600),
 		},
 	}
 	for _, tt := range tests {
@@ -422,3 +441,119 @@ func Test_Rate(t *testing.T) {
 		})
 	}
 }
+
+func Test_Irr(t *testing.T) {
+	type args struct {
+		values       []decimal.Decimal
+		maxIter      int64
+		tolerance    decimal.Decimal
+		initialGuess decimal.Decimal
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   decimal.Decimal
+		anyErr error
+	}{
+		{
+			name: "success", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-100), decimal.NewFromInt(39), decimal.NewFromInt(59), decimal.NewFromInt(55), decimal.NewFromInt(20)},
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.NewFromFloat(0.2809484211599611),
+			anyErr: nil,
+		}, {
+			name: "negative rate", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-100), decimal.NewFromInt(0), decimal.NewFromInt(0), decimal.NewFromInt(74)},
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.NewFromFloat(-0.09549583034897247),
+			anyErr: nil,
+		}, {
+			name: "multiple sign changes", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-5), decimal.NewFromFloat(10.5), decimal.NewFromInt(1), decimal.NewFromInt(-8), decimal.NewFromInt(1)},
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.NewFromFloat(0.08859833852775524),
+			anyErr: nil,
+		}, {
+			name: "failure, no sign change", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(39), decimal.NewFromInt(59)},
+				maxIter:      100,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.Zero,
+			anyErr: ErrNoSignChange,
+		}, {
+			name: "failure, not converged", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-100), decimal.NewFromInt(39), decimal.NewFromInt(59), decimal.NewFromInt(55), decimal.NewFromInt(20)},
+				maxIter:      2,
+				tolerance:    decimal.NewFromFloat(1e-10),
+				initialGuess: decimal.NewFromFloat(0.1),
+			},
+			want:   decimal.Zero,
+			anyErr: ErrTolerence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Irr(tt.args.values, tt.args.maxIter, tt.args.tolerance, tt.args.initialGuess); err != tt.anyErr || isAlmostEqual(got, tt.want, decimal.NewFromFloat(precision)) != nil {
+				t.Errorf("Irr returned (%v,%v), wanted (%v,%v)", got, err, tt.want, tt.anyErr)
+			}
+		})
+	}
+}
+
+func Test_Mirr(t *testing.T) {
+	type args struct {
+		values       []decimal.Decimal
+		financeRate  decimal.Decimal
+		reinvestRate decimal.Decimal
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   decimal.Decimal
+		anyErr error
+	}{
+		{
+			name: "success", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-120000), decimal.NewFromInt(39000), decimal.NewFromInt(30000), decimal.NewFromInt(21000), decimal.NewFromInt(37000), decimal.NewFromInt(46000)},
+				financeRate:  decimal.NewFromFloat(0.1),
+				reinvestRate: decimal.NewFromFloat(0.12),
+			},
+			want:   decimal.NewFromFloat(0.1260941303659051),
+			anyErr: nil,
+		}, {
+			name: "negative values in between", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(200), decimal.NewFromInt(-50), decimal.NewFromInt(300), decimal.NewFromInt(-200)},
+				financeRate:  decimal.NewFromFloat(0.05),
+				reinvestRate: decimal.NewFromFloat(0.06),
+			},
+			want:   decimal.NewFromFloat(0.3428233878421769),
+			anyErr: nil,
+		}, {
+			name: "failure, no sign change", args: args{
+				values:       []decimal.Decimal{decimal.NewFromInt(-100), decimal.NewFromInt(-39), decimal.NewFromInt(-59)},
+				financeRate:  decimal.NewFromFloat(0.05),
+				reinvestRate: decimal.NewFromFloat(0.06),
+			},
+			want:   decimal.Zero,
+			anyErr: ErrNoSignChange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Mirr(tt.args.values, tt.args.financeRate, tt.args.reinvestRate); err != tt.anyErr || isAlmostEqual(got, tt.want, decimal.NewFromFloat(precision)) != nil {
+				t.Errorf("Mirr returned (%v,%v), wanted (%v,%v)", got, err, tt.want, tt.anyErr)
+			}
+		})
+	}
+}
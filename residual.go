@@ -0,0 +1,121 @@
+package gofinancial
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/residualallocation"
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+/*
+ResidualAllocator settles the residual principal of a schedule, i.e. the principal left over by rounding the principal
+of every period, which is added to the principal and payment of the final row while generating the table.
+
+AllocateResidual may move the residual to other regular rows, keeping payment = principal + interest for every row and
+recording the amount added to the principal of a row in Row.Residual. The balances and totals of the rows are set
+again afterwards.
+*/
+type ResidualAllocator interface {
+	AllocateResidual(config Config, rows []Row, residual decimal.Decimal)
+}
+
+// AdjustLastInstallment leaves the residual in the final installment, which is the default.
+type AdjustLastInstallment struct{}
+
+// AllocateResidual keeps the residual in the final row.
+func (a *AdjustLastInstallment) AllocateResidual(config Config, rows []Row, residual decimal.Decimal) {
+}
+
+// AdjustFirstInstallment moves the residual to the first regular installment, so that the final installment is the
+// same as the others.
+type AdjustFirstInstallment struct{}
+
+// AllocateResidual moves the residual from the final row to the first regular row.
+func (a *AdjustFirstInstallment) AllocateResidual(config Config, rows []Row, residual decimal.Decimal) {
+	for i := range rows {
+		if rows[i].Type == rowtype.REGULAR {
+			moveResidual(&rows[len(rows)-1], &rows[i], residual)
+			return
+		}
+	}
+}
+
+// SpreadResidual spreads the residual evenly across the regular installments, in multiples of the smallest amount
+// the values are rounded to, with the earlier installments taking one more such amount if it cannot be spread evenly.
+type SpreadResidual struct{}
+
+// AllocateResidual moves a share of the residual from the final row to every other regular row.
+func (s *SpreadResidual) AllocateResidual(config Config, rows []Row, residual decimal.Decimal) {
+	var regular []int
+	for i := range rows {
+		if rows[i].Type == rowtype.REGULAR {
+			regular = append(regular, i)
+		}
+	}
+	if len(regular) == 0 {
+		return
+	}
+	unit := config.RoundingIncrement
+	if !unit.IsPositive() {
+		unit = decimal.New(1, -config.RoundingPlaces)
+	}
+	if residual.IsNegative() {
+		unit = unit.Neg()
+	}
+	units := residual.Div(unit).Round(0).IntPart()
+	count := int64(len(regular))
+	for j, i := range regular[:len(regular)-1] {
+		share := units / count
+		if int64(j) < units%count {
+			share++
+		}
+		if share == 0 {
+			break
+		}
+		moveResidual(&rows[len(rows)-1], &rows[i], unit.Mul(decimal.NewFromInt(share)))
+	}
+}
+
+// AdjustInterest keeps the final installment unchanged, and settles the residual principal against its interest.
+type AdjustInterest struct{}
+
+// AllocateResidual takes the residual out of the payment of the final row, and adjusts its interest instead.
+func (a *AdjustInterest) AllocateResidual(config Config, rows []Row, residual decimal.Decimal) {
+	final := &rows[len(rows)-1]
+	final.Payment = final.Payment.Sub(residual)
+	final.Interest = final.Interest.Sub(residual)
+}
+
+// getResidualAllocator returns the residual allocator for the residual allocation of the config.
+func getResidualAllocator(allocation residualallocation.Type) ResidualAllocator {
+	switch allocation {
+	case residualallocation.ADJUST_FIRST_INSTALLMENT:
+		return &AdjustFirstInstallment{}
+	case residualallocation.SPREAD:
+		return &SpreadResidual{}
+	case residualallocation.ADJUST_INTEREST:
+		return &AdjustInterest{}
+	default:
+		return &AdjustLastInstallment{}
+	}
+}
+
+// moveResidual moves an amount of the residual principal, along with the payment, from one row to another.
+func moveResidual(from *Row, to *Row, amount decimal.Decimal) {
+	from.Payment = from.Payment.Sub(amount)
+	from.Principal = from.Principal.Sub(amount)
+	from.Residual = from.Residual.Sub(amount)
+	to.Payment = to.Payment.Add(amount)
+	to.Principal = to.Principal.Add(amount)
+	to.Residual = to.Residual.Add(amount)
+}
+
+// GetResidual returns the total of the amounts settled in the rows due to rounding, i.e. the residual principal and
+// the differences in [payment-(principal+interest)] adjusted in the interest.
+func GetResidual(rows []Row) decimal.Decimal {
+	total := decimal.Zero
+	for _, row := range rows {
+		total = total.Add(row.Residual)
+	}
+	return total
+}
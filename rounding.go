@@ -0,0 +1,37 @@
+package gofinancial
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/roundingmode"
+)
+
+// round rounds a value in the amortization schedule as per the rounding mode, to the rounding places or to a multiple
+// of the rounding increment if specified.
+func (c *Config) round(value decimal.Decimal) decimal.Decimal {
+	if c.RoundingIncrement.IsPositive() {
+		return roundAsPerMode(value.Div(c.RoundingIncrement), 0, c.RoundingMode).Mul(c.RoundingIncrement)
+	}
+	return roundAsPerMode(value, c.RoundingPlaces, c.RoundingMode)
+}
+
+// roundAsPerMode rounds a value to the given places as per the rounding mode. As the payment, principal and interest
+// are -ve, UP and DOWN round away from and towards zero respectively, while CEILING and FLOOR round towards positive
+// and negative infinity respectively.
+func roundAsPerMode(value decimal.Decimal, places int32, mode roundingmode.Type) decimal.Decimal {
+	switch mode {
+	case roundingmode.HALF_EVEN:
+		return value.RoundBank(places)
+	case roundingmode.UP:
+		return value.RoundUp(places)
+	case roundingmode.DOWN:
+		return value.RoundDown(places)
+	case roundingmode.CEILING:
+		return value.RoundCeil(places)
+	case roundingmode.FLOOR:
+		return value.RoundFloor(places)
+	default:
+		// half away from zero.
+		return value.Round(places)
+	}
+}
@@ -0,0 +1,40 @@
+package gofinancial
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/roundingmode"
+)
+
+func TestConfig_Round(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     decimal.Decimal
+		places    int32
+		increment decimal.Decimal
+		mode      roundingmode.Type
+		want      decimal.Decimal
+	}{
+		{"half up by default", decimal.NewFromFloat(-2.5), 0, decimal.Zero, 0, decimal.NewFromInt(-3)},
+		{"half up", decimal.NewFromFloat(2.45), 1, decimal.Zero, roundingmode.HALF_UP, decimal.NewFromFloat(2.5)},
+		{"half even, down to even", decimal.NewFromFloat(-2.5), 0, decimal.Zero, roundingmode.HALF_EVEN, decimal.NewFromInt(-2)},
+		{"half even, up to even", decimal.NewFromFloat(3.5), 0, decimal.Zero, roundingmode.HALF_EVEN, decimal.NewFromInt(4)},
+		{"up, away from zero", decimal.NewFromFloat(-52871.09), 0, decimal.Zero, roundingmode.UP, decimal.NewFromInt(-52872)},
+		{"down, towards zero", decimal.NewFromFloat(-52871.99), 0, decimal.Zero, roundingmode.DOWN, decimal.NewFromInt(-52871)},
+		{"ceiling, towards positive infinity", decimal.NewFromFloat(-52871.99), 0, decimal.Zero, roundingmode.CEILING, decimal.NewFromInt(-52871)},
+		{"floor, towards negative infinity", decimal.NewFromFloat(-52871.09), 0, decimal.Zero, roundingmode.FLOOR, decimal.NewFromInt(-52872)},
+		{"nearest 10 paise", decimal.NewFromFloat(-52871.0972), 2, decimal.NewFromFloat(0.1), 0, decimal.NewFromFloat(-52871.1)},
+		{"up to the next 10", decimal.NewFromFloat(-52871.0972), 0, decimal.NewFromInt(10), roundingmode.UP, decimal.NewFromInt(-52880)},
+		{"nearest 5, half even", decimal.NewFromFloat(12.5), 0, decimal.NewFromInt(5), roundingmode.HALF_EVEN, decimal.NewFromInt(10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{EnableRounding: true, RoundingPlaces: tt.places, RoundingIncrement: tt.increment, RoundingMode: tt.mode}
+			if got := c.round(tt.value); !got.Equal(tt.want) {
+				t.Errorf("round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
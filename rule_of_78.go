@@ -0,0 +1,53 @@
+package gofinancial
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleOf78 implements financial methods for facilitating a loan use case, following a flat rate of interest in which
+// the total interest is allocated to the periods by the Rule of 78(sum of digits), i.e. in the ratio n:(n-1):...:1
+// for n periods. So the payment is the same as that of a Flat loan, but the interest is front loaded.
+type RuleOf78 struct{}
+
+// GetPrincipal returns principal amount contribution in a given period towards a loan, depending on config.
+func (r *RuleOf78) GetPrincipal(config Config, period int64) decimal.Decimal {
+	return r.GetPayment(config).Sub(r.GetInterest(config, period))
+}
+
+// GetInterest returns interest amount contribution in a given period towards a loan, depending on config.
+func (r *RuleOf78) GetInterest(config Config, period int64) decimal.Decimal {
+	minusOne := decimal.NewFromInt(-1)
+	weight := decimal.NewFromInt(config.periods - period + 1)
+	return getTotalFlatInterest(config).Mul(weight).Div(getSumOfDigits(config.periods)).Mul(minusOne)
+}
+
+// GetPayment returns the periodic payment to be done for a loan depending on config.
+func (r *RuleOf78) GetPayment(config Config) decimal.Decimal {
+	return (&Flat{}).GetPayment(config)
+}
+
+/*
+RuleOf78Rebate computes the unearned interest to be rebated when a loan following the Rule of 78 is settled early,
+right after the payment of a given period. It is the interest allocated to the remaining periods:
+
+	rebate = totalInterest * (nper-per)*(nper-per+1) / (nper*(nper+1))
+
+Params:
+
+	totalInterest	: total interest charged over all the periods
+	per		: number of periods paid before the settlement, from 0 to nper
+	nper		: total number of periods
+*/
+func RuleOf78Rebate(totalInterest decimal.Decimal, per int64, nper int64) (decimal.Decimal, error) {
+	if nper < 1 || per < 0 || per > nper {
+		return decimal.Zero, fmt.Errorf("%w: period %d in %d periods", ErrOutOfBounds, per, nper)
+	}
+	return totalInterest.Mul(getSumOfDigits(nper - per)).Div(getSumOfDigits(nper)), nil
+}
+
+// getSumOfDigits returns 1+2+...+n.
+func getSumOfDigits(n int64) decimal.Decimal {
+	return decimal.NewFromInt(n * (n + 1) / 2)
+}
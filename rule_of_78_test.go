@@ -0,0 +1,61 @@
+package gofinancial
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func Test_RuleOf78Rebate(t *testing.T) {
+	type args struct {
+		totalInterest decimal.Decimal
+		per           int64
+		nper          int64
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   decimal.Decimal
+		anyErr error
+	}{
+		{
+			name:   "settled half way",
+			args:   args{totalInterest: decimal.NewFromInt(480000), per: 12, nper: 24},
+			want:   decimal.NewFromInt(124800),
+			anyErr: nil,
+		},
+		{
+			name:   "settled before the first period",
+			args:   args{totalInterest: decimal.NewFromInt(480000), per: 0, nper: 24},
+			want:   decimal.NewFromInt(480000),
+			anyErr: nil,
+		},
+		{
+			name:   "settled after the last period",
+			args:   args{totalInterest: decimal.NewFromInt(480000), per: 24, nper: 24},
+			want:   decimal.Zero,
+			anyErr: nil,
+		},
+		{
+			name:   "twelve month loan settled after three months",
+			args:   args{totalInterest: decimal.NewFromInt(780), per: 3, nper: 12},
+			want:   decimal.NewFromInt(450),
+			anyErr: nil,
+		},
+		{
+			name:   "failure, period beyond the tenure",
+			args:   args{totalInterest: decimal.NewFromInt(480000), per: 25, nper: 24},
+			want:   decimal.Zero,
+			anyErr: ErrOutOfBounds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RuleOf78Rebate(tt.args.totalInterest, tt.args.per, tt.args.nper)
+			if !errors.Is(err, tt.anyErr) || !got.Equal(tt.want) {
+				t.Errorf("RuleOf78Rebate returned (%v,%v), wanted (%v,%v)", got, err, tt.want, tt.anyErr)
+			}
+		})
+	}
+}
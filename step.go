@@ -0,0 +1,99 @@
+package gofinancial
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+	"github.com/razorpay/go-financial/enums/steptype"
+)
+
+// Step represents a periodic change in the EMI of a REDUCING rate loan, for step-up or step-down EMI schedules.
+//
+// The EMI changes after every Periods periods, counted from the first period of the schedule, and the starting EMI is
+// solved for such that the loan is fully amortised, i.e. only the balloon amount(if any) is left after the last EMI.
+type Step struct {
+	Periods int64           // Number of periods after which the EMI changes
+	Type    steptype.Type   // Step type enum with PERCENTAGE(default) or AMOUNT value
+	Value   decimal.Decimal // Change in the EMI at every step, in percent or as an amount. The EMI steps up if +ve and steps down if -ve
+}
+
+// hasSteps returns true if the EMI changes periodically.
+func (c *Config) hasSteps() bool {
+	return c.Step.Periods > 0
+}
+
+// validateStep returns an error if the step schedule is specified for an interest type other than REDUCING, or if
+// it makes any EMI zero or -ve.
+func (c *Config) validateStep() error {
+	if c.Step.Periods < 0 {
+		return fmt.Errorf("%w: %d periods between steps", ErrInvalidStep, c.Step.Periods)
+	}
+	if !c.hasSteps() {
+		return nil
+	}
+	if c.InterestType != interesttype.REDUCING {
+		return fmt.Errorf("%w: not supported for %v interest", ErrInvalidStep, c.InterestType)
+	}
+	hundred := decimal.NewFromInt(100)
+	if c.Step.Type != steptype.AMOUNT && c.Step.Value.LessThanOrEqual(hundred.Neg()) {
+		return fmt.Errorf("%w: step of %v percent", ErrInvalidStep, c.Step.Value)
+	}
+	emi := c.getStartingEmi()
+	// the EMIs change monotonically, so it is enough to check the first and the last one.
+	if !emi.IsPositive() || !c.getSteppedEmi(emi, c.periods).IsPositive() {
+		return fmt.Errorf("%w: step of %v leaves no positive EMI to amortise %v", ErrInvalidStep, c.Step.Value, c.AmountBorrowed)
+	}
+	return nil
+}
+
+// getSteppedEmi returns the EMI(+ve) of a given period, when the EMI of the first period of the config is emi. It is
+// emi for every period if no steps are specified.
+func (c *Config) getSteppedEmi(emi decimal.Decimal, period int64) decimal.Decimal {
+	if !c.hasSteps() {
+		return emi
+	}
+	first := c.periodOffset / c.Step.Periods
+	steps := (c.periodOffset+period-1)/c.Step.Periods - first
+	if c.Step.Type == steptype.AMOUNT {
+		return emi.Add(c.Step.Value.Mul(decimal.NewFromInt(steps)))
+	}
+	hundred := decimal.NewFromInt(100)
+	factor := decimal.NewFromInt(1).Add(c.Step.Value.Div(hundred))
+	return emi.Mul(factor.Pow(decimal.NewFromInt(steps)))
+}
+
+/*
+getStartingEmi solves for the EMI(+ve) of the first period of the config, such that the present value of the stepped
+EMIs(or the level EMIs if no steps are specified) and of the balloon amount is the amount borrowed. Since every EMI is emi*a[k] + b[k] for known a[k] and b[k], i.e.
+(1+step)**j and 0 for percentage steps or 1 and step*j for amount steps, this is solved as:
+
+	emi = (pv - fv*d[n] - sum(b[k]*d[k])) / sum(a[k]*d[k])
+
+where d[k] is the discount factor for the EMI of period k, as per the rate of interest of every period, which varies
+with the length of the period if a day count convention is specified.
+*/
+func (c *Config) getStartingEmi() decimal.Decimal {
+	one := decimal.NewFromInt(1)
+	places := int32(decimal.DivisionPrecision)
+	discount := one
+	sumA := decimal.Zero
+	sumB := decimal.Zero
+	for k := int64(1); k <= c.periods; k++ {
+		factor := one.Div(one.Add(c.getInterestRateForPeriod(k)))
+		if c.PaymentPeriod != paymentperiod.BEGINNING {
+			discount = discount.Mul(factor).Round(places)
+		}
+		// b[k] is the EMI of period k when the EMI of the first period is zero.
+		b := c.getSteppedEmi(decimal.Zero, k)
+		a := c.getSteppedEmi(one, k).Sub(b)
+		sumA = sumA.Add(a.Mul(discount))
+		sumB = sumB.Add(b.Mul(discount))
+		if c.PaymentPeriod == paymentperiod.BEGINNING {
+			discount = discount.Mul(factor).Round(places)
+		}
+	}
+	return c.AmountBorrowed.Sub(c.getBalloonAmount().Mul(discount)).Sub(sumB).Div(sumA)
+}
@@ -0,0 +1,177 @@
+package gofinancial
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/paymentperiod"
+)
+
+// FieldError describes an invalid field of a Config.
+type FieldError struct {
+	Field string // Name of the field, e.g. Moratorium.Type
+	Err   error  // Reason the value is invalid, wrapping a sentinel error such as ErrInvalidFrequency
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every invalid field of a Config. It matches ErrInvalidConfig, and the sentinel error of every
+// invalid field, with errors.Is.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		reasons = append(reasons, field.Error())
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidConfig, strings.Join(reasons, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Is returns true if the error of any of the invalid fields matches the target.
+func (e *ValidationError) Is(target error) bool {
+	for _, field := range e.Fields {
+		if errors.Is(field.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// add records the error of an invalid field.
+func (e *ValidationError) add(field string, err error) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Err: err})
+}
+
+/*
+Validate checks the fields of the config on their own, and returns a *ValidationError listing every invalid field, or
+nil if there is none. It is called by NewAmortization, while the fields that depend on the periods of the schedule or
+on other fields, e.g. the periods of the prepayments, the moratorium and the balloon amount beyond the amount borrowed,
+are checked while generating the table.
+*/
+func (c *Config) Validate() error {
+	return c.validate(0)
+}
+
+// validate checks the fields of the config like Validate, except the end date if the schedule is built for a tenure.
+func (c *Config) validate(tenure int64) error {
+	result := &ValidationError{}
+	if c.StartDate.IsZero() {
+		result.add("StartDate", fmt.Errorf("%w: must be specified", ErrInvalidDate))
+	}
+	if tenure == 0 {
+		if c.EndDate.IsZero() {
+			result.add("EndDate", fmt.Errorf("%w: must be specified", ErrInvalidDate))
+		} else if c.EndDate.Before(c.StartDate) {
+			result.add("EndDate", fmt.Errorf("%w: %v is before the start date %v", ErrInvalidDate, c.EndDate, c.StartDate))
+		}
+	}
+	if !c.FirstDueDate.IsZero() && !c.FirstDueDate.After(c.StartDate) {
+		result.add("FirstDueDate", fmt.Errorf("%w: %v is not after the start date %v", ErrInvalidFirstDueDate, c.FirstDueDate, c.StartDate))
+	}
+	if c.Frequency.Value() == 0 {
+		result.add("Frequency", fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, c.Frequency))
+	}
+	if c.CompoundingFrequency != 0 && c.CompoundingFrequency.Value() == 0 {
+		result.add("CompoundingFrequency", fmt.Errorf("%w: unknown frequency %d", ErrInvalidFrequency, c.CompoundingFrequency))
+	} else if c.CompoundingFrequency != 0 && (c.InterestType == interesttype.FLAT || c.InterestType == interesttype.RULE_OF_78) {
+		// flat interest is simple interest on the amount borrowed, which does not compound.
+		result.add("CompoundingFrequency", fmt.Errorf("%w: not supported for %v interest", ErrInvalidFrequency, c.InterestType))
+	}
+	if !c.AmountBorrowed.IsPositive() {
+		result.add("AmountBorrowed", fmt.Errorf("%w: %v must be positive", ErrInvalidAmount, c.AmountBorrowed))
+	}
+	if c.InterestType.String() == "" {
+		result.add("InterestType", fmt.Errorf("%w: unknown interest type %d", ErrInvalidInterestType, c.InterestType))
+	}
+	if c.Interest.IsNegative() {
+		result.add("Interest", fmt.Errorf("%w: %v basis points must not be negative", ErrInvalidInterest, c.Interest))
+	}
+	if c.PaymentPeriod != 0 && c.PaymentPeriod != paymentperiod.BEGINNING && c.PaymentPeriod != paymentperiod.ENDING {
+		result.add("PaymentPeriod", fmt.Errorf("%w: unknown payment period %d", ErrInvalidPaymentPeriod, c.PaymentPeriod))
+	}
+	if c.RoundingPlaces != 0 && !c.EnableRounding {
+		result.add("RoundingPlaces", fmt.Errorf("%w: %d places without EnableRounding", ErrInvalidRounding, c.RoundingPlaces))
+	}
+	if c.RoundingMode != 0 && c.RoundingMode.String() == "" {
+		result.add("RoundingMode", fmt.Errorf("%w: unknown rounding mode %d", ErrInvalidRounding, c.RoundingMode))
+	} else if c.RoundingMode != 0 && !c.EnableRounding {
+		result.add("RoundingMode", fmt.Errorf("%w: %v without EnableRounding", ErrInvalidRounding, c.RoundingMode))
+	}
+	switch {
+	case c.RoundingIncrement.IsNegative():
+		result.add("RoundingIncrement", fmt.Errorf("%w: %v must not be negative", ErrInvalidRounding, c.RoundingIncrement))
+	case c.RoundingIncrement.IsPositive() && !c.EnableRounding:
+		result.add("RoundingIncrement", fmt.Errorf("%w: %v without EnableRounding", ErrInvalidRounding, c.RoundingIncrement))
+	case !c.RoundingIncrement.Equal(c.RoundingIncrement.Round(c.RoundingPlaces)):
+		result.add("RoundingIncrement", fmt.Errorf("%w: %v has more than %d decimal places", ErrInvalidRounding, c.RoundingIncrement, c.RoundingPlaces))
+	}
+	if c.RoundingErrorTolerance.IsNegative() {
+		result.add("RoundingErrorTolerance", fmt.Errorf("%w: %v must not be negative", ErrInvalidRounding, c.RoundingErrorTolerance))
+	}
+	if c.ResidualAllocation != 0 && c.ResidualAllocation.String() == "" {
+		result.add("ResidualAllocation", fmt.Errorf("%w: unknown residual allocation %d", ErrInvalidRounding, c.ResidualAllocation))
+	} else if c.ResidualAllocation != 0 && !c.EnableRounding {
+		result.add("ResidualAllocation", fmt.Errorf("%w: %v without EnableRounding", ErrInvalidRounding, c.ResidualAllocation))
+	}
+	if c.PrepaymentStrategy != 0 && c.PrepaymentStrategy.String() == "" {
+		result.add("PrepaymentStrategy", fmt.Errorf("%w: unknown prepayment strategy %d", ErrInvalidPrepayment, c.PrepaymentStrategy))
+	}
+	if c.DayCountConvention != 0 && c.DayCountConvention.String() == "" {
+		result.add("DayCountConvention", fmt.Errorf("%w: unknown day count convention %d", ErrInvalidDayCount, c.DayCountConvention))
+	}
+	if c.BrokenPeriodInterest != 0 && c.BrokenPeriodInterest.String() == "" {
+		result.add("BrokenPeriodInterest", fmt.Errorf("%w: unknown broken period interest %d", ErrInvalidBrokenPeriod, c.BrokenPeriodInterest))
+	}
+	if c.Moratorium.Periods < 0 {
+		result.add("Moratorium.Periods", fmt.Errorf("%w: %d periods must not be negative", ErrInvalidMoratorium, c.Moratorium.Periods))
+	}
+	if c.Moratorium.Type != 0 && c.Moratorium.Type.String() == "" {
+		result.add("Moratorium.Type", fmt.Errorf("%w: unknown moratorium type %d", ErrInvalidMoratorium, c.Moratorium.Type))
+	}
+	if c.RateResetStrategy != 0 && c.RateResetStrategy.String() == "" {
+		result.add("RateResetStrategy", fmt.Errorf("%w: unknown rate reset strategy %d", ErrInvalidRateReset, c.RateResetStrategy))
+	}
+	for i, prepayment := range c.Prepayments {
+		if !prepayment.Amount.IsPositive() {
+			result.add(fmt.Sprintf("Prepayments[%d].Amount", i), fmt.Errorf("%w: %v must be positive", ErrInvalidPrepayment, prepayment.Amount))
+		}
+	}
+	for i, reset := range c.RateResets {
+		if reset.Interest.IsNegative() {
+			result.add(fmt.Sprintf("RateResets[%d].Interest", i), fmt.Errorf("%w: %v basis points must not be negative", ErrInvalidRateReset, reset.Interest))
+		}
+	}
+	if c.BalloonAmount.IsNegative() {
+		result.add("BalloonAmount", fmt.Errorf("%w: %v must not be negative", ErrInvalidBalloonAmount, c.BalloonAmount))
+	}
+	if c.Step.Periods < 0 {
+		result.add("Step.Periods", fmt.Errorf("%w: %d periods must not be negative", ErrInvalidStep, c.Step.Periods))
+	}
+	if c.Step.Type != 0 && c.Step.Type.String() == "" {
+		result.add("Step.Type", fmt.Errorf("%w: unknown step type %d", ErrInvalidStep, c.Step.Type))
+	}
+	if err := c.validateBusinessDayConvention(); err != nil {
+		result.add("BusinessDayConvention", err)
+	}
+	if c.DueDayAnchor != 0 && c.DueDayAnchor.String() == "" {
+		result.add("DueDayAnchor", fmt.Errorf("%w: unknown due day anchor %d", ErrInvalidDueDayAnchor, c.DueDayAnchor))
+	}
+	if len(result.Fields) > 0 {
+		return result
+	}
+	return nil
+}
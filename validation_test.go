@@ -0,0 +1,167 @@
+package gofinancial
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/businessday"
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/moratoriumtype"
+	"github.com/razorpay/go-financial/enums/residualallocation"
+	"github.com/razorpay/go-financial/enums/roundingmode"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     func(config *Config)
+		wantFields []string
+		wantErrs   []error
+	}{
+		{
+			name:   "valid config",
+			update: func(config *Config) {},
+		},
+		{
+			name: "amount, interest and interest type",
+			update: func(config *Config) {
+				config.AmountBorrowed = decimal.Zero
+				config.Interest = decimal.NewFromInt(-100)
+				config.InterestType = interesttype.Type(0)
+			},
+			wantFields: []string{"AmountBorrowed", "InterestType", "Interest"},
+			wantErrs:   []error{ErrInvalidAmount, ErrInvalidInterestType, ErrInvalidInterest},
+		},
+		{
+			name: "end date before start date",
+			update: func(config *Config) {
+				config.EndDate = config.StartDate.AddDate(0, 0, -1)
+			},
+			wantFields: []string{"EndDate"},
+			wantErrs:   []error{ErrInvalidDate},
+		},
+		{
+			name: "missing dates and unknown frequency",
+			update: func(config *Config) {
+				config.StartDate = time.Time{}
+				config.EndDate = time.Time{}
+				config.Frequency = frequency.Type(0)
+			},
+			wantFields: []string{"StartDate", "EndDate", "Frequency"},
+			wantErrs:   []error{ErrInvalidDate, ErrInvalidFrequency},
+		},
+		{
+			name: "rounding places without rounding",
+			update: func(config *Config) {
+				config.EnableRounding = false
+				config.RoundingPlaces = 2
+				config.RoundingErrorTolerance = decimal.NewFromInt(-1)
+			},
+			wantFields: []string{"RoundingPlaces", "RoundingErrorTolerance"},
+			wantErrs:   []error{ErrInvalidRounding},
+		},
+		{
+			name: "rounding mode and increment",
+			update: func(config *Config) {
+				config.RoundingMode = roundingmode.Type(9)
+				config.RoundingIncrement = decimal.NewFromFloat(0.1)
+			},
+			wantFields: []string{"RoundingMode", "RoundingIncrement"},
+			wantErrs:   []error{ErrInvalidRounding},
+		},
+		{
+			name: "residual allocation without rounding",
+			update: func(config *Config) {
+				config.EnableRounding = false
+				config.ResidualAllocation = residualallocation.SPREAD
+			},
+			wantFields: []string{"ResidualAllocation"},
+			wantErrs:   []error{ErrInvalidRounding},
+		},
+		{
+			name: "compounding frequency of flat interest",
+			update: func(config *Config) {
+				config.InterestType = interesttype.FLAT
+				config.CompoundingFrequency = frequency.HALF_YEARLY
+			},
+			wantFields: []string{"CompoundingFrequency"},
+			wantErrs:   []error{ErrInvalidFrequency},
+		},
+		{
+			name: "compounding frequency of rule of 78 interest",
+			update: func(config *Config) {
+				config.InterestType = interesttype.RULE_OF_78
+				config.CompoundingFrequency = frequency.MONTHLY
+			},
+			wantFields: []string{"CompoundingFrequency"},
+			wantErrs:   []error{ErrInvalidFrequency},
+		},
+		{
+			name: "prepayment, rate reset and balloon amounts",
+			update: func(config *Config) {
+				config.Prepayments = []Prepayment{{Period: 2, Amount: decimal.NewFromInt(100000)}, {Period: 3, Amount: decimal.NewFromInt(-200000)}}
+				config.RateResets = []RateReset{{Date: getDate(2021, 4, 15), Interest: decimal.NewFromInt(-100)}}
+				config.BalloonAmount = decimal.NewFromInt(-1)
+			},
+			wantFields: []string{"Prepayments[1].Amount", "RateResets[0].Interest", "BalloonAmount"},
+			wantErrs:   []error{ErrInvalidPrepayment, ErrInvalidRateReset, ErrInvalidBalloonAmount},
+		},
+		{
+			name: "unknown enums",
+			update: func(config *Config) {
+				config.Moratorium = Moratorium{Periods: -1, Type: moratoriumtype.Type(9)}
+				config.BusinessDayConvention = businessday.FOLLOWING
+			},
+			wantFields: []string{"Moratorium.Periods", "Moratorium.Type", "BusinessDayConvention"},
+			wantErrs:   []error{ErrInvalidMoratorium, ErrInvalidCalendar},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getConfigDto(frequency.MONTHLY, true, interesttype.REDUCING, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+			tt.update(config)
+			err := config.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			var fields []string
+			for _, field := range validationErr.Fields {
+				fields = append(fields, field.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("Validate() fields = %v, want %v", fields, tt.wantFields)
+			}
+			for _, wantErr := range append(tt.wantErrs, ErrInvalidConfig) {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("Validate() error = %v, want it to match %v", err, wantErr)
+				}
+			}
+			if errors.Is(err, ErrInvalidStep) {
+				t.Errorf("Validate() error = %v, want it not to match %v", err, ErrInvalidStep)
+			}
+		})
+	}
+}
+
+func TestNewAmortization_InvalidConfig(t *testing.T) {
+	config := getConfigDto(frequency.MONTHLY, true, interesttype.Type(0), decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+	if _, err := NewAmortization(config); !errors.Is(err, ErrInvalidInterestType) {
+		t.Fatalf("NewAmortization() error = %v, wantErr %v", err, ErrInvalidInterestType)
+	}
+	want := "invalid config: InterestType: invalid interest type: unknown interest type 0"
+	if _, err := NewAmortization(config); err.Error() != want {
+		t.Fatalf("NewAmortization() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,345 @@
+package gofinancial
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/interesttype"
+	"github.com/razorpay/go-financial/enums/rowtype"
+)
+
+// sheet names of the workbook, in their order in the workbook.
+const (
+	summarySheet   = "Summary"
+	scheduleSheet  = "Schedule"
+	cashFlowsSheet = "Cash flows"
+)
+
+// cell styles, i.e. the indices of the cellXfs in xlsxStyles.
+const (
+	generalStyle = iota
+	dateStyle
+	headerStyle
+	percentStyle
+)
+
+// xlsxEpoch is the day 0 of the serial numbers of dates in a workbook, as per the 1900 date system of Excel.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxNamespace is the namespace of the SpreadsheetML parts of a workbook.
+const xlsxNamespace = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet3.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// xlsxWorkbook recalculates the formulas on opening the workbook, as the cached values are computed by this package.
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets>` +
+	`<sheet name="` + summarySheet + `" sheetId="1" r:id="rId1"/>` +
+	`<sheet name="` + scheduleSheet + `" sheetId="2" r:id="rId2"/>` +
+	`<sheet name="` + cashFlowsSheet + `" sheetId="3" r:id="rId3"/>` +
+	`</sheets>` +
+	`<calcPr fullCalcOnLoad="1"/>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>` +
+	`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet3.xml"/>` +
+	`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// xlsxWorksheet is the SpreadsheetML of a worksheet, with the strings inline instead of in a shared strings part.
+type xlsxWorksheet struct {
+	XMLName xml.Name  `xml:"worksheet"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Rows    []xlsxRow `xml:"sheetData>row"`
+}
+
+type xlsxRow struct {
+	Ref   int        `xml:"r,attr"`
+	Cells []xlsxCell `xml:"c"`
+}
+
+type xlsxCell struct {
+	Ref     string      `xml:"r,attr"`
+	Style   int         `xml:"s,attr,omitempty"`
+	Type    string      `xml:"t,attr,omitempty"`
+	Formula string      `xml:"f,omitempty"`
+	Value   string      `xml:"v,omitempty"`
+	Inline  *xlsxInline `xml:"is,omitempty"`
+}
+
+type xlsxInline struct {
+	Text string `xml:"t"`
+}
+
+/*
+WriteXLSX writes the rows as an Excel workbook with three sheets,
+
+	Summary		: the terms of the loan from the config, i.e. the amount borrowed, the rate of interest, the tenure
+			  and the payment, along with the total payment, the total interest, the effective annual rate and the
+			  APR(annual percentage rate) of the schedule
+	Schedule	: the rows, with the columns written by WriteCSV
+	Cash flows	: the dated cash flows of the loan as per GetCashFlows, from which the effective annual rate is derived by XIRR
+
+For REDUCING interest, the payment in the summary, and the PMT, IPMT and PPMT columns of the schedule, are live Excel
+formulas on the terms of the loan, to cross-check the schedule. These are the values of a loan with a fixed rate of
+interest and regular periods, so they differ from the schedule in the periods affected by a broken period, a moratorium,
+prepayments, rate resets or steps. The values computed by this package are cached in the formulas, and the workbook is
+recalculated on opening.
+*/
+func (a Amortization) WriteXLSX(w io.Writer, rows []Row) (err error) {
+	flows := a.GetCashFlows(rows)
+	sheets := []xlsxWorksheet{a.getSummarySheet(rows, flows), a.getScheduleSheet(rows), getCashFlowsSheet(flows)}
+	archive := zip.NewWriter(w)
+	defer func() {
+		// setting named err
+		cerr := archive.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		if err := writeZipFile(archive, part.name, []byte(part.content)); err != nil {
+			return err
+		}
+	}
+	for i, sheet := range sheets {
+		content, err := xml.Marshal(sheet)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
+		if err := writeZipFile(archive, name, append([]byte(xml.Header), content...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSummarySheet returns the sheet with the terms of the loan, a label in the first column and the value in the
+// second column of every row.
+func (a Amortization) getSummarySheet(rows []Row, flows []CashFlow) xlsxWorksheet {
+	config := a.Config
+	rate := config.getInterestRatePerPeriodInDecimal()
+	balloon := config.getBalloonAmount()
+	totalPayment, totalInterest := decimal.Zero, decimal.Zero
+	for _, row := range rows {
+		totalPayment = totalPayment.Add(row.Payment)
+		totalInterest = totalInterest.Add(row.Interest)
+	}
+	last := len(rows) + 1
+	payment := xlsxCell{Value: getFirstRegularPayment(rows).String()}
+	if config.InterestType == interesttype.REDUCING {
+		payment = xlsxCell{
+			Formula: "PMT(B4,B5,B1,-B6,B7)",
+			Value:   Pmt(rate, config.periods, config.AmountBorrowed, balloon.Neg(), config.PaymentPeriod).String(),
+		}
+	}
+	effectiveRate := xlsxCell{Formula: fmt.Sprintf("XIRR('%s'!B2:B%d,'%s'!A2:A%d)", cashFlowsSheet, len(flows)+1, cashFlowsSheet, len(flows)+1), Style: percentStyle}
+	// the APR is the rate per period of the cash flows, equivalent to the effective annual rate, times the number of
+	// periods in a year.
+	periodsPerYear := config.Frequency.Value()
+	apr := xlsxCell{Formula: fmt.Sprintf("(POWER(1+B13,1/%[1]d)-1)*%[1]d", periodsPerYear), Style: percentStyle}
+	if value, err := Xirr(flows, 100, decimal.NewFromFloat(1e-7), decimal.NewFromFloat(0.1)); err == nil {
+		effectiveRate.Value = value.String()
+		floatValue, _ := value.Float64()
+		n := float64(periodsPerYear)
+		apr.Value = decimal.NewFromFloat((math.Pow(1+floatValue, 1/n) - 1) * n).String()
+	}
+	values := []struct {
+		label string
+		cell  xlsxCell
+	}{
+		{"Amount borrowed", getNumberCell(config.AmountBorrowed)},
+		{"Annual interest rate", xlsxCell{Value: config.getInterestRateInDecimal().String(), Style: percentStyle}},
+		{"Frequency", getStringCell(config.Frequency.String())},
+		{"Rate per period", xlsxCell{Value: rate.String(), Style: percentStyle}},
+		{"Tenure", xlsxCell{Value: fmt.Sprint(config.periods)}},
+		{"Balloon amount", getNumberCell(balloon)},
+		// type argument of the Excel functions, 1 if the payments are made at the beginning of the periods.
+		{"Payment type", xlsxCell{Value: fmt.Sprint(config.PaymentPeriod.Value())}},
+		{"Start date", getDateCell(config.StartDate)},
+		{"End date", getDateCell(config.EndDate)},
+		{"Payment", payment},
+		{"Total payment", xlsxCell{Formula: fmt.Sprintf("SUM(%s!F2:F%d)", scheduleSheet, last), Value: totalPayment.String()}},
+		{"Total interest", xlsxCell{Formula: fmt.Sprintf("SUM(%s!G2:G%d)", scheduleSheet, last), Value: totalInterest.String()}},
+		{"Effective annual rate (XIRR)", effectiveRate},
+		{"APR", apr},
+	}
+	sheet := xlsxWorksheet{Xmlns: xlsxNamespace}
+	for _, value := range values {
+		label := getStringCell(value.label)
+		label.Style = headerStyle
+		sheet.addRow(label, value.cell)
+	}
+	return sheet
+}
+
+// getScheduleSheet returns the sheet with a header row and a row for every row of the schedule.
+func (a Amortization) getScheduleSheet(rows []Row) xlsxWorksheet {
+	sheet := xlsxWorksheet{Xmlns: xlsxNamespace}
+	withFormulas := a.Config.InterestType == interesttype.REDUCING
+	header := make([]xlsxCell, 0, len(csvColumns)+3)
+	columns := csvColumns
+	if withFormulas {
+		columns = append(append([]string{}, csvColumns...), "PMT", "IPMT", "PPMT")
+	}
+	for _, column := range columns {
+		cell := getStringCell(column)
+		cell.Style = headerStyle
+		header = append(header, cell)
+	}
+	sheet.addRow(header...)
+	rate := a.Config.getInterestRatePerPeriodInDecimal()
+	fv := a.Config.getBalloonAmount().Neg()
+	// arguments of the Excel functions from the summary sheet.
+	args := fmt.Sprintf("%[1]s!$B$5,%[1]s!$B$1,-%[1]s!$B$6,%[1]s!$B$7", summarySheet)
+	opts := CSVOptions{}
+	for _, row := range rows {
+		cells := make([]xlsxCell, 0, len(columns))
+		for _, column := range csvColumns {
+			switch column {
+			case "Type":
+				cells = append(cells, getStringCell(row.Type.String()))
+			case "StartDate":
+				cells = append(cells, getDateCell(row.StartDate))
+			case "EndDate":
+				cells = append(cells, getDateCell(row.EndDate))
+			default:
+				cells = append(cells, xlsxCell{Value: opts.formatField(row, column)})
+			}
+		}
+		if withFormulas && row.Type == rowtype.REGULAR {
+			period := fmt.Sprintf("B%d", len(sheet.Rows)+1)
+			cells = append(cells,
+				xlsxCell{
+					Formula: fmt.Sprintf("PMT(%s!$B$4,%s)", summarySheet, args),
+					Value:   Pmt(rate, a.Config.periods, a.Config.AmountBorrowed, fv, a.Config.PaymentPeriod).String(),
+				},
+				xlsxCell{
+					Formula: fmt.Sprintf("IPMT(%s!$B$4,%s,%s)", summarySheet, period, args),
+					Value:   IPmt(rate, row.Period, a.Config.periods, a.Config.AmountBorrowed, fv, a.Config.PaymentPeriod).String(),
+				},
+				xlsxCell{
+					Formula: fmt.Sprintf("PPMT(%s!$B$4,%s,%s)", summarySheet, period, args),
+					Value:   PPmt(rate, row.Period, a.Config.periods, a.Config.AmountBorrowed, fv, a.Config.PaymentPeriod).String(),
+				},
+			)
+		}
+		sheet.addRow(cells...)
+	}
+	return sheet
+}
+
+// getCashFlowsSheet returns the sheet with the date and the amount of every cash flow.
+func getCashFlowsSheet(flows []CashFlow) xlsxWorksheet {
+	sheet := xlsxWorksheet{Xmlns: xlsxNamespace}
+	date, amount := getStringCell("Date"), getStringCell("Amount")
+	date.Style, amount.Style = headerStyle, headerStyle
+	sheet.addRow(date, amount)
+	for _, flow := range flows {
+		sheet.addRow(getDateCell(flow.Date), getNumberCell(flow.Amount))
+	}
+	return sheet
+}
+
+// getFirstRegularPayment returns the payment of the first regular row, if any.
+func getFirstRegularPayment(rows []Row) decimal.Decimal {
+	for _, row := range rows {
+		if row.Type == rowtype.REGULAR {
+			return row.Payment
+		}
+	}
+	return decimal.Zero
+}
+
+// addRow appends a row with the cells in consecutive columns, setting the references of the row and the cells.
+func (s *xlsxWorksheet) addRow(cells ...xlsxCell) {
+	ref := len(s.Rows) + 1
+	for i := range cells {
+		cells[i].Ref = fmt.Sprintf("%s%d", getColumnName(i), ref)
+	}
+	s.Rows = append(s.Rows, xlsxRow{Ref: ref, Cells: cells})
+}
+
+// getColumnName returns the name of a column from its index, i.e. A for 0, Z for 25 and AA for 26.
+func getColumnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func getStringCell(value string) xlsxCell {
+	return xlsxCell{Type: "inlineStr", Inline: &xlsxInline{Text: value}}
+}
+
+func getNumberCell(value decimal.Decimal) xlsxCell {
+	return xlsxCell{Value: value.String()}
+}
+
+// getDateCell returns a cell with the serial number of the date, leaving out the time of the day.
+func getDateCell(date time.Time) xlsxCell {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return xlsxCell{Value: fmt.Sprint(int(day.Sub(xlsxEpoch).Hours() / 24)), Style: dateStyle}
+}
+
+// writeZipFile writes a file with the content to the archive.
+func writeZipFile(archive *zip.Writer, name string, content []byte) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	return err
+}
@@ -0,0 +1,158 @@
+package gofinancial
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/razorpay/go-financial/enums/frequency"
+	"github.com/razorpay/go-financial/enums/interesttype"
+)
+
+func TestAmortization_WriteXLSX(t *testing.T) {
+	tests := []struct {
+		name         string
+		interestType interesttype.Type
+		wantCells    map[string]map[string]xlsxCell
+	}{
+		{
+			name:         "reducing",
+			interestType: interesttype.REDUCING,
+			wantCells: map[string]map[string]xlsxCell{
+				"xl/worksheets/sheet1.xml": {
+					"A1":  {Inline: &xlsxInline{Text: "Amount borrowed"}},
+					"B1":  {Value: "1000000"},
+					"B2":  {Value: "0.24"},
+					"B3":  {Inline: &xlsxInline{Text: "monthly"}},
+					"B5":  {Value: "24"},
+					"B8":  {Value: "43936"},
+					"B9":  {Value: "44665"},
+					"B10": {Formula: "PMT(B4,B5,B1,-B6,B7)", Value: "-52871.0973"},
+					"B11": {Formula: "SUM(Schedule!F2:F25)", Value: "-1268904"},
+					"B12": {Formula: "SUM(Schedule!G2:G25)", Value: "-268904"},
+					"A13": {Inline: &xlsxInline{Text: "Effective annual rate (XIRR)"}},
+					"B13": {Formula: "XIRR('Cash flows'!B2:B26,'Cash flows'!A2:A26)", Value: "0.2687"},
+					"A14": {Inline: &xlsxInline{Text: "APR"}},
+					"B14": {Formula: "(POWER(1+B13,1/12)-1)*12", Value: "0.2404"},
+				},
+				"xl/worksheets/sheet2.xml": {
+					"A1":  {Inline: &xlsxInline{Text: "Type"}},
+					"P1":  {Inline: &xlsxInline{Text: "PPMT"}},
+					"A2":  {Inline: &xlsxInline{Text: "regular"}},
+					"B2":  {Value: "1"},
+					"C2":  {Value: "43936"},
+					"F2":  {Value: "-52871"},
+					"N2":  {Formula: "PMT(Summary!$B$4,Summary!$B$5,Summary!$B$1,-Summary!$B$6,Summary!$B$7)", Value: "-52871.0973"},
+					"O2":  {Formula: "IPMT(Summary!$B$4,B2,Summary!$B$5,Summary!$B$1,-Summary!$B$6,Summary!$B$7)", Value: "-20000"},
+					"P25": {Formula: "PPMT(Summary!$B$4,B25,Summary!$B$5,Summary!$B$1,-Summary!$B$6,Summary!$B$7)", Value: "-51834.4091"},
+				},
+				"xl/worksheets/sheet3.xml": {
+					"A2":  {Value: "43936"},
+					"B2":  {Value: "1000000"},
+					"A26": {Value: "44665"},
+					"B26": {Value: "-52871"},
+				},
+			},
+		},
+		{
+			name:         "flat",
+			interestType: interesttype.FLAT,
+			wantCells: map[string]map[string]xlsxCell{
+				"xl/worksheets/sheet1.xml": {
+					"B10": {Value: "-61667"},
+					// the flat rate is charged on the amount borrowed, so the APR is much higher than the 24% flat rate.
+					"B14": {Formula: "(POWER(1+B13,1/12)-1)*12", Value: "0.4096"},
+				},
+				"xl/worksheets/sheet2.xml": {
+					"M1": {Inline: &xlsxInline{Text: "CumulativePrincipal"}},
+					"N1": {},
+					"N2": {},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getConfigDto(frequency.MONTHLY, true, tt.interestType, decimal.NewFromInt(1000000), decimal.NewFromInt(2400), 0)
+			a, err := NewAmortization(config)
+			if err != nil {
+				t.Fatalf("NewAmortization() call failed. error = %v", err)
+			}
+			rows, err := a.GenerateTable()
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := a.WriteXLSX(&buf, rows); err != nil {
+				t.Fatalf("WriteXLSX() error = %v", err)
+			}
+			sheets := readXLSX(t, buf.Bytes())
+			for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+				if _, ok := sheets[name]; !ok {
+					t.Fatalf("part %s missing in the workbook", name)
+				}
+			}
+			for name, want := range tt.wantCells {
+				var sheet xlsxWorksheet
+				if err := xml.Unmarshal(sheets[name], &sheet); err != nil {
+					t.Fatalf("error parsing %s: %v", name, err)
+				}
+				cells := make(map[string]xlsxCell)
+				for _, row := range sheet.Rows {
+					for _, cell := range row.Cells {
+						cells[cell.Ref] = cell
+					}
+				}
+				for ref, wantCell := range want {
+					verifyCell(t, name, ref, cells[ref], wantCell)
+				}
+			}
+		})
+	}
+}
+
+// readXLSX returns the content of every file in the workbook.
+func readXLSX(t *testing.T, content []byte) map[string][]byte {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("error reading the workbook: %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		f, err := file.Open()
+		if err != nil {
+			t.Fatalf("error opening %s: %v", file.Name, err)
+		}
+		files[file.Name], err = ioutil.ReadAll(f)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", file.Name, err)
+		}
+		f.Close()
+	}
+	return files
+}
+
+// verifyCell compares the formula and the text of a cell, and its numeric value up to the places of the wanted value.
+func verifyCell(t *testing.T, sheet string, ref string, got xlsxCell, want xlsxCell) {
+	if got.Formula != want.Formula {
+		t.Fatalf("formula mismatch in %s!%s, want=%v, got=%v", sheet, ref, want.Formula, got.Formula)
+	}
+	if (want.Inline == nil) != (got.Inline == nil) || want.Inline != nil && got.Inline.Text != want.Inline.Text {
+		t.Fatalf("text mismatch in %s!%s, want=%+v, got=%+v", sheet, ref, want.Inline, got.Inline)
+	}
+	if want.Value == "" {
+		if got.Value != "" {
+			t.Fatalf("value mismatch in %s!%s, want no value, got=%v", sheet, ref, got.Value)
+		}
+		return
+	}
+	wantValue := decimal.RequireFromString(want.Value)
+	gotValue, err := decimal.NewFromString(got.Value)
+	if err != nil || !gotValue.Round(-wantValue.Exponent()).Equal(wantValue) {
+		t.Fatalf("value mismatch in %s!%s, want=%v, got=%v", sheet, ref, want.Value, got.Value)
+	}
+}